@@ -0,0 +1,250 @@
+package qb
+
+import (
+	"sort"
+	"strings"
+)
+
+// AdviseIndexes inspects the WHERE clauses and join conditions of a set of
+// queries and suggests indexes that could serve them. For each table, the
+// columns compared for equality in a single AND-ed condition are suggested
+// together, in the order they first appear, followed by at most one column
+// compared with a range operator, since an index can't be used beyond the
+// first range column. Conditions under an OR are ignored, while subqueries
+// are inspected in their own right. Suggestions that are a prefix of a longer
+// one for the same table are dropped, as the longer index serves both.
+//
+// The suggestions are only a starting point: they don't account for existing
+// indexes, selectivity or write overhead, and should be checked against the
+// query plans before being applied.
+func AdviseIndexes(queries ...Query) []CreateIndexQuery {
+	a := advisor{seen: make(map[string]bool)}
+	for _, q := range queries {
+		a.walk(q)
+	}
+
+	sort.Slice(a.candidates, func(i, j int) bool {
+		ci, cj := a.candidates[i], a.candidates[j]
+		if ci.Table != cj.Table {
+			return ci.Table < cj.Table
+		}
+		return strings.Join(ci.Columns, ",") < strings.Join(cj.Columns, ",")
+	})
+
+	var indexes []CreateIndexQuery
+	for i, c := range a.candidates {
+		covered := false
+		for j, other := range a.candidates {
+			if i != j && other.Table == c.Table && len(other.Columns) > len(c.Columns) && isPrefix(c.Columns, other.Columns) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			indexes = append(indexes, c)
+		}
+	}
+	return indexes
+}
+
+type advisor struct {
+	candidates []CreateIndexQuery
+	seen       map[string]bool
+}
+
+func (a *advisor) suggest(table string, columns ...string) {
+	if table == "" || len(columns) == 0 {
+		return
+	}
+	key := table + "(" + strings.Join(columns, ",") + ")"
+	if a.seen[key] {
+		return
+	}
+	a.seen[key] = true
+	name := strings.ReplaceAll(table, ".", "_") + "_" + strings.Join(columns, "_") + "_idx"
+	a.candidates = append(a.candidates, CreateIndex(name, table, columns...))
+}
+
+func (a *advisor) walk(q Query) {
+	switch q := q.(type) {
+	case SelectQuery:
+		q = q.scoped()
+		if q.Source != nil {
+			a.walk(q.Source)
+		}
+		for _, expr := range q.Exprs {
+			a.walk(expr)
+		}
+		a.where(q.Table, q.WhereClause)
+		a.where(q.Table, q.HavingClause)
+	case DeleteQuery:
+		a.where(q.Table, q.WhereClause)
+	case UpdateQuery:
+		a.where(q.Table, q.WhereClause)
+	case JoinQuery:
+		a.walk(q.Query1)
+		a.walk(q.Query2)
+		ons := []Query{q.OnClause}
+		for _, j := range q.Joins {
+			a.walk(j.Query)
+			ons = append(ons, j.OnClause)
+		}
+		for _, on := range ons {
+			if on, ok := on.(On); ok {
+				for _, field := range []string{on.Field1, on.Field2} {
+					if table, column := splitColumn(field); table != "" {
+						a.suggest(table, column)
+					}
+				}
+			}
+		}
+	case AliasClause:
+		a.walk(q.Query)
+	case CreateTableAsQuery:
+		a.walk(q.Query)
+	case ExplainQuery:
+		a.walk(q.Query)
+	case AnnotatedQuery:
+		a.walk(q.Query)
+	case AuditQuery:
+		a.walk(q.Query)
+	case CaptureQuery:
+		a.walk(q.Query)
+	case CopyQuery:
+		a.walk(q.Query)
+	}
+}
+
+// where suggests indexes for the predicates in a WHERE clause, where
+// unqualified columns belong to table.
+func (a *advisor) where(table string, where Query) {
+	if where == nil {
+		return
+	}
+
+	type predicates struct {
+		eq  []string
+		rng string
+	}
+	byTable := make(map[string]*predicates)
+	var tables []string
+	add := func(field string, isRange bool) {
+		t, column := splitColumn(field)
+		if t == "" {
+			t = table
+		}
+		p, ok := byTable[t]
+		if !ok {
+			p = &predicates{}
+			byTable[t] = p
+			tables = append(tables, t)
+		}
+		switch {
+		case !isRange && !contains(p.eq, column):
+			p.eq = append(p.eq, column)
+		case isRange && p.rng == "":
+			p.rng = column
+		}
+	}
+
+	var terms []Query
+	var collect func(Query)
+	collect = func(q Query) {
+		if f, ok := q.(Filter); ok {
+			q = f.Cond
+		}
+		for _, t := range flattenTerms("AND", q) {
+			if f, ok := t.(Filter); ok {
+				collect(f.Cond)
+				continue
+			}
+			terms = append(terms, t)
+		}
+	}
+	collect(where)
+
+	for _, t := range terms {
+		switch t := t.(type) {
+		case ComparisonClause:
+			if sub, ok := t.Value.(Query); ok {
+				if _, ok := sub.(scalar); !ok {
+					a.walk(sub)
+				}
+			}
+			if !identPattern.MatchString(t.Field) {
+				continue
+			}
+			switch t.Op {
+			case "=":
+				add(t.Field, false)
+			case "<", "<=", ">", ">=":
+				add(t.Field, true)
+			}
+		case AnyClause:
+			add(t.Field, false)
+		case InClause:
+			add(t.Field, false)
+		case BetweenClause:
+			if !t.Not && identPattern.MatchString(t.Field) {
+				add(t.Field, true)
+			}
+		case BooleanQuery:
+			a.subqueries(t)
+		}
+	}
+
+	for _, t := range tables {
+		p := byTable[t]
+		columns := p.eq
+		if p.rng != "" && !contains(columns, p.rng) {
+			columns = append(columns[:len(columns):len(columns)], p.rng)
+		}
+		a.suggest(t, columns...)
+	}
+}
+
+// subqueries inspects the subqueries nested in a condition whose own
+// predicates can't be used for an index.
+func (a *advisor) subqueries(q Query) {
+	switch q := q.(type) {
+	case BooleanQuery:
+		a.subqueries(q.Comparison1)
+		a.subqueries(q.Comparison2)
+	case Filter:
+		a.subqueries(q.Cond)
+	case ComparisonClause:
+		if sub, ok := q.Value.(Query); ok {
+			a.walk(sub)
+		}
+	}
+}
+
+// splitColumn splits a possibly qualified column name into its table and
+// column.
+func splitColumn(field string) (string, string) {
+	if i := strings.LastIndex(field, "."); i >= 0 {
+		return field[:i], field[i+1:]
+	}
+	return "", field
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func isPrefix(prefix, list []string) bool {
+	if len(prefix) > len(list) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != list[i] {
+			return false
+		}
+	}
+	return true
+}
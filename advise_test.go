@@ -0,0 +1,43 @@
+package qb_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestAdviseIndexes(t *testing.T) {
+	queries := []qb.Query{
+		qb.Select("vehicles", "id").Where(qb.And(
+			qb.Equal("make", "Honda"),
+			qb.And(qb.Greater("cost", 1000), qb.Equal("model", "Civic")),
+		)),
+		qb.Select("vehicles", "id").Where(qb.Equal("make", "Honda")),
+		qb.Select("photos", "url").Where(qb.Or(
+			qb.Equal("public", true),
+			qb.Equal("vehicle_id", qb.Select("vehicles", "id").Where(qb.Equal("vin", "123"))),
+		)),
+		qb.Join(
+			qb.Select("employees", "id").Where(qb.Equal("employees.role", "admin")),
+			qb.Select("dealerships", "name"),
+		).On("employees.dealership_id", "dealerships.id"),
+		qb.Delete("sessions").Where(qb.Less("expires_at", 100)),
+	}
+
+	var got []string
+	for _, idx := range qb.AdviseIndexes(queries...) {
+		got = append(got, idx.Build())
+	}
+	want := []string{
+		`CREATE INDEX dealerships_id_idx ON dealerships (id)`,
+		`CREATE INDEX employees_dealership_id_idx ON employees (dealership_id)`,
+		`CREATE INDEX employees_role_idx ON employees (role)`,
+		`CREATE INDEX sessions_expires_at_idx ON sessions (expires_at)`,
+		`CREATE INDEX vehicles_make_model_cost_idx ON vehicles (make, model, cost)`,
+		`CREATE INDEX vehicles_vin_idx ON vehicles (vin)`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\n\twanted:\n%v\n\tgot:\n%v", want, got)
+	}
+}
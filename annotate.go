@@ -0,0 +1,94 @@
+package qb
+
+import (
+	"context"
+	"strings"
+)
+
+type annotationKey struct{}
+
+// WithAnnotation returns a context carrying a short annotation, such as a
+// request ID, to be included in queries built with AnnotateContext.
+func WithAnnotation(ctx context.Context, annotation string) context.Context {
+	return context.WithValue(ctx, annotationKey{}, annotation)
+}
+
+// AnnotationFromContext returns the annotation stored in the context by
+// WithAnnotation, if any.
+func AnnotationFromContext(ctx context.Context) (string, bool) {
+	annotation, ok := ctx.Value(annotationKey{}).(string)
+	return annotation, ok && annotation != ""
+}
+
+// AnnotateContext prefixes the query with the annotation stored in the context
+// so that the statement can be correlated with the request that issued it in
+// pg_stat_activity, the slow query log or deadlock reports. Queries are
+// returned unchanged if the context has no annotation.
+func AnnotateContext(ctx context.Context, q Query) Query {
+	annotation, ok := AnnotationFromContext(ctx)
+	if !ok {
+		return q
+	}
+	return Annotate(q, annotation)
+}
+
+// Annotate returns a query that resolves to the form `/* comment */ query`.
+// Only letters, digits, spaces and the characters `_.:=,-` are kept in the
+// comment; everything else is dropped, so that it can't open or close a
+// comment however the characters are arranged.
+func Annotate(q Query, comment string) AnnotatedQuery {
+	return AnnotatedQuery{
+		Query:   q,
+		Comment: comment,
+	}
+}
+
+// AnnotatedQuery represents a query with a leading comment.
+type AnnotatedQuery struct {
+	Query   Query
+	Comment string
+}
+
+// Build returns a query string of the form `/* comment */ query`.
+func (q AnnotatedQuery) Build() string {
+	return "/* " + sanitizeComment(q.Comment) + " */ " + q.Query.Build()
+}
+
+func (q AnnotatedQuery) String() string {
+	return q.Build()
+}
+
+// Values returns the values for the annotated query.
+func (q AnnotatedQuery) Values() []interface{} {
+	return q.Query.Values()
+}
+
+// ToSql is like SelectQuery.ToSql.
+func (q AnnotatedQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(q)
+}
+
+// BuildFor is like SelectQuery.BuildFor.
+func (q AnnotatedQuery) BuildFor(d Dialect) string {
+	return BuildFor(q, d)
+}
+
+// Kind returns the kind of the annotated query.
+func (q AnnotatedQuery) Kind() Kind {
+	return KindOf(q.Query)
+}
+
+// sanitizeComment removes every character from s that isn't safe inside a
+// block comment. It is used for both annotations and optimizer hints, which
+// need the parentheses.
+func sanitizeComment(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case strings.ContainsRune(" _.:=,-()", r):
+			return r
+		}
+		return -1
+	}, s)
+}
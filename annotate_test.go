@@ -0,0 +1,49 @@
+package qb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestAnnotate(t *testing.T) {
+	q := qb.Select("vehicles", "id").Where(qb.Equal("make", "Honda"))
+	ctx := qb.WithAnnotation(context.Background(), "request_id=abc123")
+
+	testcases := []testcase{
+		testcase{
+			name:  "annotated from context",
+			query: qb.AnnotateContext(ctx, q),
+			want: output{
+				query: `/* request_id=abc123 */ SELECT id FROM vehicles WHERE make = ?`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name:  "context without annotation",
+			query: qb.AnnotateContext(context.Background(), q),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE make = ?`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name:  "comment cannot escape",
+			query: qb.Annotate(qb.Delete("vehicles"), "*/ DROP TABLE vehicles; /*"),
+			want: output{
+				query: `/*  DROP TABLE vehicles  */ DELETE FROM vehicles`,
+			},
+		},
+		testcase{
+			name:  "overlapping delimiters",
+			query: qb.Annotate(qb.Select("users", "id"), "/*/ DROP TABLE users; --"),
+			want: output{
+				query: `/*  DROP TABLE users -- */ SELECT id FROM users`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
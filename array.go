@@ -0,0 +1,86 @@
+package qb
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Any returns a boolean clause that resolves to the Postgres form `field =
+// ANY(?)`, binding the whole list of values as a single array parameter. Unlike
+// an IN list, the query string is the same no matter how many values there are,
+// so it can be prepared once and doesn't run into placeholder limits. The
+// values are passed to the driver as-is; pgx encodes Go slices as arrays
+// natively, while lib/pq requires them to be wrapped with pq.Array.
+func Any(field string, values interface{}) AnyClause {
+	return AnyClause{
+		Field: field,
+		Value: values,
+	}
+}
+
+// AnyClause represents a comparison of a column with every element of an array
+// parameter.
+type AnyClause struct {
+	Field string
+	Value interface{}
+}
+
+// Build returns a clause of the form `field = ANY(?)`.
+func (c AnyClause) Build() string {
+	return fmt.Sprintf("%s = ANY(?)", c.Field)
+}
+
+func (c AnyClause) String() string {
+	return c.Build()
+}
+
+// Values returns the array as a single value.
+func (c AnyClause) Values() []interface{} {
+	return []interface{}{c.Value}
+}
+
+// matchIn evaluates an IN clause the same way as the equivalent ANY clause.
+// Without any values there is nothing to compare against, since they are bound
+// later by the caller.
+func matchIn(c InClause, row map[string]interface{}) (bool, error) {
+	switch len(c.Vals) {
+	case 0:
+		return false, fmt.Errorf("qb: %w: IN without values on %s", ErrCannotMatch, c.Field)
+	case 1:
+		if _, ok := c.Vals[0].([]byte); ok {
+			break
+		}
+		if v := reflect.ValueOf(c.Vals[0]); v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+			return matchAny(AnyClause{Field: c.Field, Value: c.Vals[0]}, row)
+		}
+	}
+	return matchAny(AnyClause{Field: c.Field, Value: c.Vals}, row)
+}
+
+func matchAny(c AnyClause, row map[string]interface{}) (bool, error) {
+	lhs, err := lookupField(row, c.Field)
+	if err != nil {
+		return false, err
+	}
+	v := reflect.ValueOf(c.Value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return false, fmt.Errorf("qb: %w: ANY value of type %T on %s", ErrCannotMatch, c.Value, c.Field)
+	}
+	if lhs == nil {
+		return false, nil
+	}
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i).Interface()
+		if elem == nil {
+			continue
+		}
+		cmp, err := compareValues(lhs, elem)
+		if err != nil {
+			return false, fmt.Errorf("qb: compare %s: %w", c.Field, err)
+		}
+		if cmp == 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
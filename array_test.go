@@ -0,0 +1,34 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestAny(t *testing.T) {
+	ids := []int64{1, 2, 3}
+	testcases := []testcase{
+		testcase{
+			name:  "any",
+			query: qb.Select("vehicles", "make").Where(qb.And(qb.Any("id", ids), qb.Equal("year", 2020))),
+			want: output{
+				query: `SELECT make FROM vehicles WHERE (id = ANY(?) AND year = ?)`,
+				vals:  []interface{}{ids, 2020},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+
+	for id, want := range map[int]bool{2: true, 4: false} {
+		got, err := qb.Match(qb.Any("id", ids), map[string]interface{}{"id": id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("id %d: wanted %t, got %t", id, want, got)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package qb
+
+import "fmt"
+
+// Audit wraps an UPDATE or DELETE so that the rows it changes are copied to the
+// history table registered for the table beforehand, giving change capture
+// without triggers. It uses Postgres data-modifying CTEs, so the copy and the
+// change are made by a single statement. An error is returned for any other
+// kind of query, or if the table wasn't registered with a HistoryTable.
+func Audit(q Query) (AuditQuery, error) {
+	var table string
+	switch q := q.(type) {
+	case UpdateQuery:
+		table = q.Table
+	case DeleteQuery:
+		table = q.Table
+	default:
+		return AuditQuery{}, fmt.Errorf("qb: can't audit a %T", q)
+	}
+	meta, ok := LookupTable(table)
+	if !ok || meta.HistoryTable == "" {
+		return AuditQuery{}, fmt.Errorf("qb: table %s has no history table", table)
+	}
+	return AuditQuery{
+		Query:        q,
+		HistoryTable: meta.HistoryTable,
+	}, nil
+}
+
+// AuditQuery represents an UPDATE or DELETE that also inserts the prior
+// version of every affected row into a history table.
+type AuditQuery struct {
+	Query        Query
+	HistoryTable string
+}
+
+// Build returns a statement of the form `WITH old AS (DELETE FROM table WHERE
+// expr RETURNING *) INSERT INTO history SELECT * FROM old` for a DELETE. Since
+// RETURNING reports the new version of updated rows, an UPDATE reads the old
+// rows first, in the form `WITH old AS (SELECT * FROM table WHERE expr FOR
+// UPDATE), changed AS (UPDATE ...) INSERT INTO history SELECT * FROM old`.
+func (q AuditQuery) Build() string {
+	switch sq := q.Query.(type) {
+	case UpdateQuery:
+		old := "SELECT * FROM " + sq.Table
+		if sq.WhereClause != nil {
+			old += " WHERE " + sq.WhereClause.Build()
+		}
+		return fmt.Sprintf("WITH old AS (%s FOR UPDATE), changed AS (%s) INSERT INTO %s SELECT * FROM old", old, sq.Build(), q.HistoryTable)
+	case DeleteQuery:
+		return fmt.Sprintf("WITH old AS (%s RETURNING *) INSERT INTO %s SELECT * FROM old", sq.Build(), q.HistoryTable)
+	}
+	return q.Query.Build()
+}
+
+func (q AuditQuery) String() string {
+	return Dump(q)
+}
+
+// Values returns the values of the audited query. For an UPDATE, the values of
+// its WHERE clause come first, since the clause appears twice.
+func (q AuditQuery) Values() []interface{} {
+	if sq, ok := q.Query.(UpdateQuery); ok {
+		return append(sq.Vals[:len(sq.Vals):len(sq.Vals)], sq.Values()...)
+	}
+	return q.Query.Values()
+}
+
+// ToSql is like SelectQuery.ToSql.
+func (q AuditQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(q)
+}
+
+// BuildFor is like SelectQuery.BuildFor.
+func (q AuditQuery) BuildFor(d Dialect) string {
+	return BuildFor(q, d)
+}
+
+// Kind returns the kind of the audited query.
+func (q AuditQuery) Kind() Kind {
+	return KindOf(q.Query)
+}
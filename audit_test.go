@@ -0,0 +1,54 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestAudit(t *testing.T) {
+	qb.RegisterTable(qb.TableMeta{
+		Name:         "invoices",
+		HistoryTable: "invoices_history",
+	})
+
+	update, err := qb.Audit(qb.Update("invoices").Set("status", "paid").Where(qb.Equal("id", 7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	del, err := qb.Audit(qb.Delete("invoices").Where(qb.Equal("id", 7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:  "update",
+			query: update,
+			want: output{
+				query: `WITH old AS (SELECT * FROM invoices WHERE id = ? FOR UPDATE), changed AS (UPDATE invoices SET status = ? WHERE id = ?) INSERT INTO invoices_history SELECT * FROM old`,
+				vals:  []interface{}{7, "paid", 7},
+			},
+		},
+		testcase{
+			name:  "delete",
+			query: del,
+			want: output{
+				query: `WITH old AS (DELETE FROM invoices WHERE id = ? RETURNING *) INSERT INTO invoices_history SELECT * FROM old`,
+				vals:  []interface{}{7},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
+
+func TestAuditErrors(t *testing.T) {
+	if _, err := qb.Audit(qb.Delete("vehicles")); err == nil {
+		t.Error("expected an error for a table without a history table")
+	}
+	if _, err := qb.Audit(qb.Select("invoices")); err == nil {
+		t.Error("expected an error for a SELECT")
+	}
+}
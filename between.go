@@ -0,0 +1,61 @@
+package qb
+
+import "fmt"
+
+// Between returns a boolean clause that resolves to the form `(field BETWEEN
+// lo AND hi)`, which includes both bounds. As with a comparison, either bound
+// can be a column or a subquery.
+func Between(field string, lo, hi interface{}) BetweenClause {
+	return BetweenClause{
+		Field: field,
+		Low:   lo,
+		High:  hi,
+	}
+}
+
+// NotBetween returns a boolean clause that resolves to the form `(field NOT
+// BETWEEN lo AND hi)`.
+func NotBetween(field string, lo, hi interface{}) BetweenClause {
+	return BetweenClause{
+		Field: field,
+		Low:   lo,
+		High:  hi,
+		Not:   true,
+	}
+}
+
+// BetweenClause represents a range check on a column.
+type BetweenClause struct {
+	Field string
+	Low   interface{}
+	High  interface{}
+	Not   bool
+}
+
+// Build returns a clause of the form `field [NOT] BETWEEN ? AND ?`.
+func (c BetweenClause) Build() string {
+	op := "BETWEEN"
+	if c.Not {
+		op = "NOT BETWEEN"
+	}
+	return fmt.Sprintf("%s %s %s AND %s", c.Field, op, buildArg(c.Low), buildArg(c.High))
+}
+
+func (c BetweenClause) String() string {
+	return c.Build()
+}
+
+// Values returns the values of the lower bound followed by those of the upper
+// bound.
+func (c BetweenClause) Values() []interface{} {
+	return append(argValues(c.Low), argValues(c.High)...)
+}
+
+// matchBetween evaluates a range check as the equivalent pair of comparisons,
+// so that nothing is ever between or not between NULL.
+func matchBetween(c BetweenClause, row map[string]interface{}) (bool, error) {
+	if c.Not {
+		return Match(Or(Less(c.Field, c.Low), Greater(c.Field, c.High)), row)
+	}
+	return Match(And(GreaterEqual(c.Field, c.Low), LessEqual(c.Field, c.High)), row)
+}
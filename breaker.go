@@ -0,0 +1,103 @@
+package qb
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by CircuitBreaker.Allow for queries whose circuit
+// is open.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// NewCircuitBreaker returns a breaker that opens the circuit for a query after
+// threshold consecutive failures and keeps it open for cooldown.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		Threshold: threshold,
+		Cooldown:  cooldown,
+		circuits:  make(map[string]*circuit),
+	}
+}
+
+// CircuitBreaker tracks failures by query fingerprint and stops a query from
+// being executed once it has failed repeatedly, protecting the database from a
+// storm of retries of a query that is consistently failing. Once the cooldown
+// has passed, a single execution is let through as a probe; the circuit closes
+// again if it succeeds and stays open for another cooldown if it fails.
+//
+// Like LatencyRecorder, a CircuitBreaker is meant to be called by whatever
+// executes queries, and is safe for concurrent use.
+type CircuitBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	failures int
+	open     bool
+	openedAt time.Time
+	probing  bool
+}
+
+// Allow returns an error wrapping ErrCircuitOpen if q shouldn't be executed
+// right now. Every call that returns nil must be followed by a call to Record
+// with the outcome.
+func (b *CircuitBreaker) Allow(q Query) error {
+	fp := Fingerprint(q)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[fp]
+	if !ok || !c.open {
+		return nil
+	}
+	if c.probing || time.Since(c.openedAt) < b.Cooldown {
+		return fmt.Errorf("qb: %w for query %s", ErrCircuitOpen, fp)
+	}
+	c.probing = true
+	return nil
+}
+
+// Record reports the outcome of an execution of q that was allowed by Allow.
+func (b *CircuitBreaker) Record(q Query, err error) {
+	fp := Fingerprint(q)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[fp]
+	if err == nil {
+		if ok {
+			delete(b.circuits, fp)
+		}
+		return
+	}
+	if !ok {
+		c = &circuit{}
+		b.circuits[fp] = c
+	}
+	c.failures++
+	if c.probing || c.failures >= b.Threshold {
+		c.open = true
+		c.openedAt = time.Now()
+		c.probing = false
+	}
+}
+
+// Do executes fn if the circuit for q allows it and records the outcome. It
+// returns the error from fn, or an error wrapping ErrCircuitOpen if fn wasn't
+// called.
+func (b *CircuitBreaker) Do(q Query, fn func() error) error {
+	if err := b.Allow(q); err != nil {
+		return err
+	}
+	err := fn()
+	b.Record(q, err)
+	return err
+}
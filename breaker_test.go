@@ -0,0 +1,50 @@
+package qb_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := qb.NewCircuitBreaker(2, 20*time.Millisecond)
+	failing := qb.Select("vehicles").Where(qb.Equal("make", "Honda"))
+	healthy := qb.Select("dealerships")
+	boom := errors.New("boom")
+	fail := func() error { return boom }
+	succeed := func() error { return nil }
+
+	for i := 0; i < 2; i++ {
+		if err := b.Do(failing, fail); err != boom {
+			t.Fatalf("attempt %d: expected the query error, got %v", i, err)
+		}
+	}
+	if err := b.Do(qb.Select("vehicles").Where(qb.Equal("make", "Acura")), succeed); !errors.Is(err, qb.ErrCircuitOpen) {
+		t.Fatalf("expected the circuit to be open for the same query shape, got %v", err)
+	}
+	if err := b.Do(healthy, succeed); err != nil {
+		t.Errorf("expected other queries to be unaffected, got %v", err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if err := b.Allow(failing); err != nil {
+		t.Fatalf("expected a probe to be allowed after the cooldown, got %v", err)
+	}
+	if err := b.Allow(failing); !errors.Is(err, qb.ErrCircuitOpen) {
+		t.Errorf("expected only one probe at a time, got %v", err)
+	}
+	b.Record(failing, boom)
+	if err := b.Allow(failing); !errors.Is(err, qb.ErrCircuitOpen) {
+		t.Errorf("expected a failed probe to reopen the circuit, got %v", err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if err := b.Do(failing, succeed); err != nil {
+		t.Fatalf("expected the probe to run, got %v", err)
+	}
+	if err := b.Do(failing, fail); err != boom {
+		t.Errorf("expected a successful probe to close the circuit, got %v", err)
+	}
+}
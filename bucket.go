@@ -0,0 +1,92 @@
+package qb
+
+import (
+	"fmt"
+	"time"
+)
+
+// DateTrunc returns an expression that resolves to the form
+// `date_trunc('unit', field)`, truncating a timestamp to the start of the day,
+// hour, etc. The unit is rendered as a literal rather than bound so that the
+// same expression can be repeated in GROUP BY e.g.
+//
+//	day := qb.DateTrunc("day", "created_at")
+//	qb.Select("orders", "COUNT(*)").Expr(qb.As(day, "day")).GroupBy(day.Build())
+func DateTrunc(unit, field string) DateTruncExpr {
+	return DateTruncExpr{
+		Unit:  unit,
+		Field: field,
+	}
+}
+
+// DateTruncExpr represents a timestamp truncated to a unit of time.
+type DateTruncExpr struct {
+	Unit  string
+	Field string
+}
+
+// Build returns an expression of the form `date_trunc('unit', field)`.
+func (e DateTruncExpr) Build() string {
+	return fmt.Sprintf("date_trunc(%s, %s)", quoteLiteral(e.Unit), e.Field)
+}
+
+func (e DateTruncExpr) String() string {
+	return e.Build()
+}
+
+// Values always returns nil for DateTruncExpr.
+func (e DateTruncExpr) Values() []interface{} {
+	return nil
+}
+
+func (e DateTruncExpr) scalar() {}
+
+// TimeBucket returns an expression that resolves to the form
+// `time_bucket(INTERVAL '...', field)` using the TimescaleDB function, which
+// groups timestamps into buckets of any width, such as 15 minutes. Like
+// DateTrunc, it has no values and can be repeated in GROUP BY. Use
+// WithoutTimescale on databases without the extension.
+func TimeBucket(field string, width time.Duration) TimeBucketExpr {
+	return TimeBucketExpr{
+		Field: field,
+		Width: width,
+	}
+}
+
+// TimeBucketExpr represents a timestamp rounded down to a bucket of fixed
+// width.
+type TimeBucketExpr struct {
+	Field    string
+	Width    time.Duration
+	Emulated bool
+}
+
+// WithoutTimescale computes the bucket from the Unix epoch using standard
+// Postgres functions instead of time_bucket. Buckets are aligned to the epoch
+// rather than to the Monday that time_bucket uses for widths of a week or
+// more.
+func (e TimeBucketExpr) WithoutTimescale() TimeBucketExpr {
+	e.Emulated = true
+	return e
+}
+
+// Build returns an expression of the form `time_bucket(INTERVAL '...', field)`,
+// or `to_timestamp(floor(extract(epoch FROM field) / n) * n)` if emulated.
+func (e TimeBucketExpr) Build() string {
+	if e.Emulated {
+		secs := formatFloat(e.Width.Seconds(), 64)
+		return fmt.Sprintf("to_timestamp(floor(extract(epoch FROM %s) / %s) * %s)", e.Field, secs, secs)
+	}
+	return fmt.Sprintf("time_bucket(%s, %s)", Interval(e.Width).Build(), e.Field)
+}
+
+func (e TimeBucketExpr) String() string {
+	return e.Build()
+}
+
+// Values always returns nil for TimeBucketExpr.
+func (e TimeBucketExpr) Values() []interface{} {
+	return nil
+}
+
+func (e TimeBucketExpr) scalar() {}
@@ -0,0 +1,44 @@
+package qb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestBuckets(t *testing.T) {
+	day := qb.DateTrunc("day", "created_at")
+	quarter := qb.TimeBucket("created_at", 15*time.Minute)
+
+	testcases := []testcase{
+		testcase{
+			name: "date trunc",
+			query: qb.Select("orders", "COUNT(*)").
+				Expr(qb.As(day, "day")).
+				Where(qb.Equal("status", "paid")).
+				GroupBy(day.Build()),
+			want: output{
+				query: `SELECT COUNT(*), date_trunc('day', created_at) AS day FROM orders WHERE status = ? GROUP BY date_trunc('day', created_at)`,
+				vals:  []interface{}{"paid"},
+			},
+		},
+		testcase{
+			name:  "time bucket",
+			query: qb.Select("readings", "AVG(value)").Expr(qb.As(quarter, "bucket")).GroupBy("bucket"),
+			want: output{
+				query: `SELECT AVG(value), time_bucket(INTERVAL '15 minutes', created_at) AS bucket FROM readings GROUP BY bucket`,
+			},
+		},
+		testcase{
+			name:  "emulated time bucket",
+			query: quarter.WithoutTimescale(),
+			want: output{
+				query: `to_timestamp(floor(extract(epoch FROM created_at) / 900) * 900)`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
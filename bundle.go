@@ -0,0 +1,115 @@
+package qb
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// BundleMode controls how the values of the statements in a Bundle are
+// written.
+type BundleMode int
+
+const (
+	// InlineLiterals writes every value as an escaped SQL literal in place of
+	// its placeholder.
+	InlineLiterals BundleMode = iota
+
+	// PsqlVariables writes the values of each statement as psql `\set`
+	// commands preceding it and refers to them as `:'p1'`, so the values can
+	// be reviewed and changed separately from the statement. NULLs are always
+	// inlined since psql variables can't hold them.
+	PsqlVariables
+)
+
+// NewBundle returns an empty bundle that writes values using mode.
+func NewBundle(mode BundleMode) *Bundle {
+	return &Bundle{Mode: mode}
+}
+
+// Bundle collects statements in order so they can be written out as a single
+// .sql script, for example for a DBA to review and run by hand instead of
+// executing them directly.
+type Bundle struct {
+	Mode       BundleMode
+	Statements []Query
+}
+
+// Add appends statements to the bundle.
+func (b *Bundle) Add(qs ...Query) {
+	b.Statements = append(b.Statements, qs...)
+}
+
+// WriteTo writes the bundle as an SQL script, with each statement preceded by
+// a comment giving its position and followed by a semicolon.
+func (b *Bundle) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// String returns the bundle as an SQL script.
+func (b *Bundle) String() string {
+	var sb strings.Builder
+	param := 0
+	for i, q := range b.Statements {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "-- [%d/%d]\n", i+1, len(b.Statements))
+		switch b.Mode {
+		case PsqlVariables:
+			sb.WriteString(psqlVariables(q, &param))
+		default:
+			sb.WriteString(inline(q))
+		}
+		sb.WriteString(";\n")
+	}
+	return sb.String()
+}
+
+// psqlVariables returns `\set` commands for the values of q followed by the
+// statement referring to them. Variables are numbered from *param onwards so
+// that they are unique within a script.
+func psqlVariables(q Query, param *int) string {
+	sql := q.Build()
+	vals := q.Values()
+
+	var sets, stmt strings.Builder
+	last, n := 0, 0
+	scanPlaceholders(sql, func(offset int) {
+		stmt.WriteString(sql[last:offset])
+		last = offset + 1
+		if n >= len(vals) {
+			stmt.WriteString("?")
+			return
+		}
+		v := vals[n]
+		n++
+		if v == nil {
+			stmt.WriteString("NULL")
+			return
+		}
+		*param++
+		name := fmt.Sprintf("p%d", *param)
+		fmt.Fprintf(&sets, "\\set %s '%s'\n", name, psqlEscape(literalText(v)))
+		fmt.Fprintf(&stmt, ":'%s'", name)
+	})
+	stmt.WriteString(sql[last:])
+	return sets.String() + stmt.String()
+}
+
+// literalText returns the text of a value without the quotes it would have as
+// an SQL literal.
+func literalText(v interface{}) string {
+	lit := literal(v)
+	if len(lit) >= 2 && lit[0] == '\'' && lit[len(lit)-1] == '\'' {
+		return strings.ReplaceAll(lit[1:len(lit)-1], "''", "'")
+	}
+	return lit
+}
+
+// psqlEscape escapes s for use inside a single-quoted psql meta-command
+// argument, where backslash sequences are interpreted.
+func psqlEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `''`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(s)
+}
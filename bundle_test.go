@@ -0,0 +1,53 @@
+package qb_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestBundle(t *testing.T) {
+	statements := []qb.Query{
+		qb.Delete("vehicles").Where(qb.And(qb.Equal("make", "O'Reilly"), qb.Less("cost", 100))),
+		qb.Raw("UPDATE vehicles SET notes = ? WHERE id = ?", nil, 7),
+		qb.Raw(`SELECT '?' AS q, ?`, `C:\cars`),
+	}
+
+	testcases := map[qb.BundleMode]string{
+		qb.InlineLiterals: `-- [1/3]
+DELETE FROM vehicles WHERE (make = 'O''Reilly' AND cost < 100);
+
+-- [2/3]
+UPDATE vehicles SET notes = NULL WHERE id = 7;
+
+-- [3/3]
+SELECT '?' AS q, 'C:\cars';
+`,
+		qb.PsqlVariables: `-- [1/3]
+\set p1 'O''Reilly'
+\set p2 '100'
+DELETE FROM vehicles WHERE (make = :'p1' AND cost < :'p2');
+
+-- [2/3]
+\set p3 '7'
+UPDATE vehicles SET notes = NULL WHERE id = :'p3';
+
+-- [3/3]
+\set p4 'C:\\cars'
+SELECT '?' AS q, :'p4';
+`,
+	}
+	for mode, want := range testcases {
+		b := qb.NewBundle(mode)
+		b.Add(statements...)
+
+		var buf bytes.Buffer
+		if _, err := b.WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != want {
+			t.Errorf("mode %d:\n\twanted:\n%s\n\tgot:\n%s", mode, want, got)
+		}
+	}
+}
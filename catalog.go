@@ -0,0 +1,273 @@
+package qb
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CatalogSpec is the document format read by LoadCatalog. It maps query names to
+// their definitions. The struct tags support both YAML and JSON documents.
+type CatalogSpec struct {
+	Queries map[string]QuerySpec `yaml:"queries" json:"queries"`
+}
+
+// QuerySpec defines a single SELECT in a query catalog.
+type QuerySpec struct {
+	// Table is the table to select from.
+	Table string `yaml:"table" json:"table"`
+
+	// Fields is the list of columns to select. All columns are selected if it
+	// is empty.
+	Fields []string `yaml:"fields" json:"fields"`
+
+	// Filters are combined with AND to form the WHERE clause.
+	Filters []FilterSpec `yaml:"filters" json:"filters"`
+
+	// Join optionally joins a second table to the query.
+	Join *JoinSpec `yaml:"join" json:"join"`
+
+	// Order is a list of sort terms of the form `-created_at`, as accepted by
+	// ParseSort. Ordering isn't supported for joined queries.
+	Order []string `yaml:"order" json:"order"`
+
+	// Hints are optimizer hints added to the query with SelectQuery.Hint.
+	Hints []string `yaml:"hints" json:"hints"`
+
+	// Limit caps the number of rows returned, as with SelectQuery.Limit. It is
+	// a pointer so that an override can remove the cap by setting it to zero.
+	// Limits aren't supported for joined queries.
+	Limit *int `yaml:"limit" json:"limit"`
+
+	// Environments holds overrides keyed by environment name. Any field that
+	// is set in an override replaces the corresponding field of the base spec
+	// when the catalog is loaded for that environment.
+	Environments map[string]QuerySpec `yaml:"environments" json:"environments"`
+}
+
+// FilterSpec defines a single comparison in a catalog query.
+type FilterSpec struct {
+	Field string      `yaml:"field" json:"field"`
+	Op    string      `yaml:"op" json:"op"`
+	Value interface{} `yaml:"value" json:"value"`
+}
+
+// JoinSpec defines the second table of a joined catalog query.
+type JoinSpec struct {
+	Table   string       `yaml:"table" json:"table"`
+	Fields  []string     `yaml:"fields" json:"fields"`
+	Filters []FilterSpec `yaml:"filters" json:"filters"`
+
+	// On is the pair of columns the tables are joined on.
+	On [2]string `yaml:"on" json:"on"`
+}
+
+var comparisonOps = map[string]func(string, interface{}) ComparisonClause{
+	"=":        Equal,
+	"!=":       NotEqual,
+	">":        Greater,
+	">=":       GreaterEqual,
+	"<":        Less,
+	"<=":       LessEqual,
+	"LIKE":     Like,
+	"NOT LIKE": NotLike,
+}
+
+// LoadCatalog is equivalent to LoadCatalogFor with no environment, so no
+// overrides are applied.
+func LoadCatalog(data []byte, unmarshal func([]byte, interface{}) error) (*Catalog, error) {
+	return LoadCatalogFor("", data, unmarshal)
+}
+
+// LoadCatalogFor reads a catalog of named queries and materializes them as qb
+// queries, so that queries such as reports can be adjusted without
+// recompiling. The document is decoded with unmarshal, which is typically
+// yaml.Unmarshal from the YAML library of the caller's choice; since YAML is a
+// superset of JSON, json.Unmarshal works too for JSON documents.
+//
+// Every query is checked when the catalog is loaded. Columns are validated
+// against the table registry for tables that were registered with their
+// Columns.
+//
+// Overrides for env are resolved before the queries are built, and the
+// resulting specs can be inspected with Catalog.Spec.
+func LoadCatalogFor(env string, data []byte, unmarshal func([]byte, interface{}) error) (*Catalog, error) {
+	var spec CatalogSpec
+	if err := unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("qb: decode catalog: %w", err)
+	}
+
+	c := &Catalog{
+		env:     env,
+		specs:   make(map[string]QuerySpec, len(spec.Queries)),
+		queries: make(map[string]Query, len(spec.Queries)),
+	}
+	for name, qs := range spec.Queries {
+		qs = qs.For(env)
+		q, err := qs.Query()
+		if err != nil {
+			return nil, fmt.Errorf("qb: catalog query %s: %w", name, err)
+		}
+		c.specs[name] = qs
+		c.queries[name] = q
+	}
+	return c, nil
+}
+
+// Catalog is a set of named queries loaded by LoadCatalog.
+type Catalog struct {
+	env     string
+	specs   map[string]QuerySpec
+	queries map[string]Query
+}
+
+// Get returns the named query.
+func (c *Catalog) Get(name string) (Query, bool) {
+	q, ok := c.queries[name]
+	return q, ok
+}
+
+// Spec returns the effective spec of the named query, with the overrides for
+// the catalog's environment already applied.
+func (c *Catalog) Spec(name string) (QuerySpec, bool) {
+	s, ok := c.specs[name]
+	return s, ok
+}
+
+// Env returns the environment the catalog was loaded for.
+func (c *Catalog) Env() string {
+	return c.env
+}
+
+// Names returns the names of every query in the catalog in sorted order.
+func (c *Catalog) Names() []string {
+	names := make([]string, 0, len(c.queries))
+	for name := range c.queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// For returns the spec with the overrides for env applied. The returned spec
+// has no environments of its own.
+func (s QuerySpec) For(env string) QuerySpec {
+	o, ok := s.Environments[env]
+	s.Environments = nil
+	if !ok {
+		return s
+	}
+	if o.Table != "" {
+		s.Table = o.Table
+	}
+	if o.Fields != nil {
+		s.Fields = o.Fields
+	}
+	if o.Filters != nil {
+		s.Filters = o.Filters
+	}
+	if o.Join != nil {
+		s.Join = o.Join
+	}
+	if o.Order != nil {
+		s.Order = o.Order
+	}
+	if o.Hints != nil {
+		s.Hints = o.Hints
+	}
+	if o.Limit != nil {
+		s.Limit = o.Limit
+	}
+	return s
+}
+
+// Query validates the spec and builds the query it describes.
+func (s QuerySpec) Query() (Query, error) {
+	sq, err := selectFromSpec(s.Table, s.Fields, s.Filters)
+	if err != nil {
+		return nil, err
+	}
+	for _, term := range s.Order {
+		field, dir := parseSortTerm(term)
+		if err := checkColumn(s.Table, field); err != nil {
+			return nil, err
+		}
+		sq = sq.Sort(OrderByClause{{Field: field, Dir: dir}})
+	}
+	for _, hint := range s.Hints {
+		sq = sq.Hint(hint)
+	}
+	if s.Limit != nil {
+		if *s.Limit < 0 {
+			return nil, fmt.Errorf("negative limit %d", *s.Limit)
+		}
+		sq = sq.Limit(*s.Limit)
+	}
+
+	if s.Join == nil {
+		return sq, nil
+	}
+	if len(s.Order) > 0 {
+		return nil, fmt.Errorf("ordering isn't supported for joined queries")
+	}
+	if s.Limit != nil {
+		return nil, fmt.Errorf("limits aren't supported for joined queries")
+	}
+	jq, err := selectFromSpec(s.Join.Table, s.Join.Fields, s.Join.Filters)
+	if err != nil {
+		return nil, err
+	}
+	if s.Join.On[0] == "" || s.Join.On[1] == "" {
+		return nil, fmt.Errorf("join with %s is missing its on columns", s.Join.Table)
+	}
+	return Join(sq, jq).On(s.Join.On[0], s.Join.On[1]), nil
+}
+
+func selectFromSpec(table string, fields []string, filters []FilterSpec) (SelectQuery, error) {
+	if table == "" {
+		return SelectQuery{}, fmt.Errorf("missing table")
+	}
+	for _, field := range fields {
+		if err := checkColumn(table, field); err != nil {
+			return SelectQuery{}, err
+		}
+	}
+	q := Select(table, fields...)
+
+	var where Query
+	for _, f := range filters {
+		op, ok := comparisonOps[f.Op]
+		if !ok {
+			return SelectQuery{}, fmt.Errorf("unknown operator %q", f.Op)
+		}
+		if err := checkColumn(table, f.Field); err != nil {
+			return SelectQuery{}, err
+		}
+		var cond Query = op(f.Field, f.Value)
+		if where != nil {
+			cond = And(where, cond)
+		}
+		where = cond
+	}
+	if where != nil {
+		q = q.Where(where)
+	}
+	return q, nil
+}
+
+// checkColumn reports an error if the table was registered with a list of
+// columns that doesn't include column.
+func checkColumn(table, column string) error {
+	if column == "" {
+		return fmt.Errorf("empty column for table %s", table)
+	}
+	meta, ok := LookupTable(table)
+	if !ok || len(meta.Columns) == 0 {
+		return nil
+	}
+	for _, c := range meta.Columns {
+		if c == column {
+			return nil
+		}
+	}
+	return fmt.Errorf("table %s has no column %s", table, column)
+}
@@ -0,0 +1,125 @@
+package qb_test
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestLoadCatalog(t *testing.T) {
+	qb.RegisterTable(qb.TableMeta{
+		Name:    "catalog_vehicles",
+		Columns: []string{"id", "make", "cost", "created_at"},
+	})
+
+	data, err := os.ReadFile("testdata/catalog.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	catalog, err := qb.LoadCatalog(data, json.Unmarshal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, got := []string{"dealership_staff", "recent_hondas"}, catalog.Names(); !reflect.DeepEqual(want, got) {
+		t.Errorf("\n\twanted:\n%v\n\tgot:\n%v", want, got)
+	}
+
+	hondas, _ := catalog.Get("recent_hondas")
+	staff, _ := catalog.Get("dealership_staff")
+	testcases := []testcase{
+		testcase{
+			name:  "select",
+			query: hondas,
+			want: output{
+				query: `SELECT id, cost FROM catalog_vehicles WHERE (make = ? AND cost < ?) ORDER BY created_at DESC, id ASC`,
+				vals:  []interface{}{"Honda", float64(20000)},
+			},
+		},
+		testcase{
+			name:  "join",
+			query: staff,
+			want: output{
+				query: `SELECT employees.id, dealerships.name FROM employees, dealerships WHERE employees.dealership_id = dealerships.id AND (state = ?)`,
+				vals:  []interface{}{"NY"},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+
+	if _, ok := catalog.Get("missing"); ok {
+		t.Error("expected missing query to be absent")
+	}
+}
+
+func TestLoadCatalogValidation(t *testing.T) {
+	qb.RegisterTable(qb.TableMeta{
+		Name:    "catalog_dealerships",
+		Columns: []string{"id", "name"},
+	})
+
+	invalid := map[string]string{
+		"unknown column":   `{"queries": {"q": {"table": "catalog_dealerships", "fields": ["vin"]}}}`,
+		"unknown filter":   `{"queries": {"q": {"table": "catalog_dealerships", "filters": [{"field": "state", "op": "=", "value": 1}]}}}`,
+		"unknown order":    `{"queries": {"q": {"table": "catalog_dealerships", "order": ["-created_at"]}}}`,
+		"unknown operator": `{"queries": {"q": {"table": "t", "filters": [{"field": "a", "op": "~", "value": 1}]}}}`,
+		"missing table":    `{"queries": {"q": {"fields": ["id"]}}}`,
+		"join without on":  `{"queries": {"q": {"table": "a", "join": {"table": "b"}}}}`,
+		"negative limit":   `{"queries": {"q": {"table": "a", "limit": -1}}}`,
+		"join with limit":  `{"queries": {"q": {"table": "a", "limit": 1, "join": {"table": "b", "on": ["a.id", "b.a_id"]}}}}`,
+		"malformed":        `{"queries": [`,
+	}
+	for name, doc := range invalid {
+		if _, err := qb.LoadCatalog([]byte(doc), json.Unmarshal); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLoadCatalogFor(t *testing.T) {
+	doc := `{"queries": {"report": {
+		"table": "vehicles",
+		"fields": ["id"],
+		"order": ["id"],
+		"limit": 100,
+		"environments": {
+			"prod": {"hints": ["IndexScan(vehicles vehicles_pkey)"], "limit": 0},
+			"staging": {"fields": ["id", "make"], "limit": 10}
+		}
+	}}}`
+
+	testcases := map[string]string{
+		"":        `SELECT id FROM vehicles ORDER BY id ASC LIMIT ?`,
+		"prod":    `SELECT /*+ IndexScan(vehicles vehicles_pkey) */ id FROM vehicles ORDER BY id ASC`,
+		"staging": `SELECT id, make FROM vehicles ORDER BY id ASC LIMIT ?`,
+		"dev":     `SELECT id FROM vehicles ORDER BY id ASC LIMIT ?`,
+	}
+	for env, want := range testcases {
+		catalog, err := qb.LoadCatalogFor(env, []byte(doc), json.Unmarshal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q, _ := catalog.Get("report")
+		if got := q.Build(); got != want {
+			t.Errorf("%q: \n\twanted:\n%s\n\tgot:\n%s", env, want, got)
+		}
+		spec, ok := catalog.Spec("report")
+		if !ok {
+			t.Fatalf("%q: missing spec", env)
+		}
+		if spec.Environments != nil {
+			t.Errorf("%q: expected the effective spec to have no environments", env)
+		}
+		if env == "prod" && !reflect.DeepEqual(spec.Hints, []string{"IndexScan(vehicles vehicles_pkey)"}) {
+			t.Errorf("%q: unexpected hints %v", env, spec.Hints)
+		}
+		if env == "staging" && !reflect.DeepEqual(q.Values(), []interface{}{10}) {
+			t.Errorf("%q: unexpected values %v", env, q.Values())
+		}
+	}
+}
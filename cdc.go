@@ -0,0 +1,135 @@
+package qb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ChangeEvent describes a change made to a single row by an UPDATE or DELETE.
+// PK holds the primary key of the row, keyed by column, and Changed lists the
+// columns assigned by an UPDATE.
+type ChangeEvent struct {
+	Table   string
+	Op      string
+	PK      map[string]interface{}
+	Changed []string
+}
+
+// ChangeSink receives the events for a statement once it has succeeded, for
+// example to write them to an outbox table in the same transaction.
+type ChangeSink func(ctx context.Context, events []ChangeEvent) error
+
+// Capture wraps an UPDATE or DELETE so that it reports the primary key of every
+// row it changes using RETURNING, which is available on Postgres, SQLite and
+// MariaDB. The statement should be run with QueryContext and its rows passed
+// to Emit:
+//
+//	cq, err := qb.Capture(q)
+//	if err != nil {
+//		return err
+//	}
+//	rows, err := tx.QueryContext(ctx, cq.Build(), cq.Values()...)
+//	if err != nil {
+//		return err
+//	}
+//	defer rows.Close()
+//	if err := cq.Emit(ctx, rows, sink); err != nil {
+//		return err
+//	}
+//
+// The primary key is taken from the table's registered metadata, defaulting to
+// `id` as for ByPK. An error is returned for any other kind of query.
+func Capture(q Query) (CaptureQuery, error) {
+	var table string
+	switch q := q.(type) {
+	case UpdateQuery:
+		table = q.Table
+	case DeleteQuery:
+		table = q.Table
+	default:
+		return CaptureQuery{}, fmt.Errorf("qb: can't capture changes of a %T", q)
+	}
+	return CaptureQuery{
+		Query:      q,
+		PrimaryKey: primaryKey(table),
+	}, nil
+}
+
+// CaptureQuery represents an UPDATE or DELETE that returns the primary key of
+// every row it changes.
+type CaptureQuery struct {
+	Query      Query
+	PrimaryKey []string
+}
+
+// Build returns the statement followed by `RETURNING pk`.
+func (q CaptureQuery) Build() string {
+	return fmt.Sprintf("%s RETURNING %s", q.Query.Build(), strings.Join(q.PrimaryKey, ", "))
+}
+
+func (q CaptureQuery) String() string {
+	return Dump(q)
+}
+
+// Values returns the values of the captured statement.
+func (q CaptureQuery) Values() []interface{} {
+	return q.Query.Values()
+}
+
+// ToSql is like SelectQuery.ToSql.
+func (q CaptureQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(q)
+}
+
+// BuildFor is like SelectQuery.BuildFor.
+func (q CaptureQuery) BuildFor(d Dialect) string {
+	return BuildFor(q, d)
+}
+
+// Kind returns the kind of the captured statement.
+func (q CaptureQuery) Kind() Kind {
+	return KindOf(q.Query)
+}
+
+// Events reads the rows returned by the statement and returns one event per
+// changed row. The rows are not closed.
+func (q CaptureQuery) Events(rows Rows) ([]ChangeEvent, error) {
+	base := ChangeEvent{}
+	switch sq := q.Query.(type) {
+	case UpdateQuery:
+		base.Table, base.Op = sq.Table, "UPDATE"
+		for _, a := range sq.Assignments {
+			base.Changed = append(base.Changed, a.Field)
+		}
+	case DeleteQuery:
+		base.Table, base.Op = sq.Table, "DELETE"
+	default:
+		return nil, fmt.Errorf("qb: can't capture changes of a %T", q.Query)
+	}
+
+	var events []ChangeEvent
+	err := scanRows(rows, len(q.PrimaryKey), func(vals []interface{}) error {
+		e := base
+		e.PK = make(map[string]interface{}, len(vals))
+		for i, col := range q.PrimaryKey {
+			e.PK[col] = facetValue(vals[i])
+		}
+		events = append(events, e)
+		return nil
+	})
+	return events, err
+}
+
+// Emit reads the rows returned by the statement and passes the events to sink.
+// The sink isn't called if no rows were changed. The rows are not closed.
+func (q CaptureQuery) Emit(ctx context.Context, rows Rows, sink ChangeSink) error {
+	events, err := q.Events(rows)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	return sink(ctx, events)
+}
@@ -0,0 +1,82 @@
+package qb_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestCapture(t *testing.T) {
+	update, err := qb.Capture(qb.Update("vehicles").Set("cost", 9000).Where(qb.Equal("make", "Honda")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	del, err := qb.Capture(qb.Delete("vehicles").Where(qb.Equal("sold", true)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:  "update",
+			query: update,
+			want: output{
+				query: `UPDATE vehicles SET cost = ? WHERE make = ? RETURNING id`,
+				vals:  []interface{}{9000, "Honda"},
+			},
+		},
+		testcase{
+			name:  "delete",
+			query: del,
+			want: output{
+				query: `DELETE FROM vehicles WHERE sold = ? RETURNING id`,
+				vals:  []interface{}{true},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+
+	if _, err := qb.Capture(qb.Select("vehicles")); err == nil {
+		t.Error("expected an error for a SELECT")
+	}
+}
+
+func TestCaptureEmit(t *testing.T) {
+	q, err := qb.Capture(qb.Update("vehicles").Set("cost", 9000).Set("sold", true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []qb.ChangeEvent
+	sink := func(ctx context.Context, events []qb.ChangeEvent) error {
+		got = append(got, events...)
+		return nil
+	}
+	rows := &fakeRows{rows: [][]interface{}{{int64(1)}, {int64(2)}}}
+	if err := q.Emit(context.Background(), rows, sink); err != nil {
+		t.Fatal(err)
+	}
+	want := []qb.ChangeEvent{
+		{Table: "vehicles", Op: "UPDATE", PK: map[string]interface{}{"id": int64(1)}, Changed: []string{"cost", "sold"}},
+		{Table: "vehicles", Op: "UPDATE", PK: map[string]interface{}{"id": int64(2)}, Changed: []string{"cost", "sold"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\n\twanted:\n%v\n\tgot:\n%v", want, got)
+	}
+
+	called := false
+	sink = func(context.Context, []qb.ChangeEvent) error {
+		called = true
+		return nil
+	}
+	if err := q.Emit(context.Background(), &fakeRows{}, sink); err != nil {
+		t.Fatal(err)
+	}
+	if called {
+		t.Error("sink called without any changes")
+	}
+}
@@ -0,0 +1,118 @@
+package qb
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ColumnRef describes a single column in the result of a query.
+type ColumnRef struct {
+	// Table is the table or relation the column is read from. It is empty for
+	// computed expressions.
+	Table string
+
+	// Name is the name of the column in the result set. For expressions that
+	// weren't given an alias it is the text of the expression, since the name
+	// chosen by the database varies.
+	Name string
+}
+
+// String returns the column in the form `table.name`, or just `name` for
+// computed expressions.
+func (c ColumnRef) String() string {
+	if c.Table == "" {
+		return c.Name
+	}
+	return c.Table + "." + c.Name
+}
+
+// ResultColumns returns the columns produced by the query, in order. A `*`
+// field list is expanded using the columns of a VALUES relation, a table
+// function or the registered Columns of the table, and is returned as a single
+// column named `*` if the columns aren't known.
+func (q SelectQuery) ResultColumns() []ColumnRef {
+	q = q.scoped()
+	var cols []ColumnRef
+	if len(q.Fields) == 0 && len(q.Exprs) == 0 {
+		cols = q.starColumns()
+	}
+	for _, field := range q.Fields {
+		cols = append(cols, fieldColumn(q.Table, field))
+	}
+	for _, expr := range q.Exprs {
+		cols = append(cols, exprColumn(expr))
+	}
+	return cols
+}
+
+// ResultColumns returns the columns produced by the join, in order. Columns
+// from every table are qualified with their table names.
+func (q JoinQuery) ResultColumns() []ColumnRef {
+	q = q.scoped()
+	var cols []ColumnRef
+	for _, sq := range q.queries() {
+		if len(sq.Fields) == 0 && len(sq.Exprs) == 0 {
+			cols = append(cols, sq.starColumns()...)
+		}
+		for _, field := range sq.Fields {
+			cols = append(cols, fieldColumn(sq.Table, field))
+		}
+	}
+	for _, sq := range q.queries() {
+		for _, expr := range sq.Exprs {
+			cols = append(cols, exprColumn(expr))
+		}
+	}
+	return cols
+}
+
+func (q SelectQuery) starColumns() []ColumnRef {
+	var names []string
+	if v, ok := q.Source.(ValuesTableClause); ok {
+		names = v.Columns
+	} else if f, ok := q.Source.(TableFuncClause); ok {
+		names = f.columns()
+	} else if meta, ok := LookupTable(q.Table); ok && q.Source == nil {
+		names = meta.Columns
+	}
+	if len(names) == 0 {
+		return []ColumnRef{{Table: q.Table, Name: "*"}}
+	}
+	cols := make([]ColumnRef, 0, len(names))
+	for _, name := range names {
+		cols = append(cols, ColumnRef{Table: q.Table, Name: name})
+	}
+	return cols
+}
+
+var (
+	identPattern = regexp.MustCompile(`^(?:([A-Za-z_][A-Za-z0-9_]*)\.)?([A-Za-z_][A-Za-z0-9_]*)$`)
+	aliasPattern = regexp.MustCompile(`(?i)\s+AS\s+([A-Za-z_][A-Za-z0-9_]*)$`)
+)
+
+// fieldColumn describes an entry in a field list, which is usually a plain or
+// qualified column name but may also be an expression with or without an
+// alias.
+func fieldColumn(table, field string) ColumnRef {
+	field = strings.TrimSpace(field)
+	if m := identPattern.FindStringSubmatch(field); m != nil {
+		if m[1] != "" {
+			table = m[1]
+		}
+		return ColumnRef{Table: table, Name: m[2]}
+	}
+	if m := aliasPattern.FindStringSubmatch(field); m != nil {
+		return ColumnRef{Name: m[1]}
+	}
+	return ColumnRef{Name: field}
+}
+
+func exprColumn(q Query) ColumnRef {
+	switch q := q.(type) {
+	case AliasClause:
+		return ColumnRef{Name: q.Alias}
+	case Column:
+		return fieldColumn("", string(q))
+	}
+	return ColumnRef{Name: q.Build()}
+}
@@ -0,0 +1,66 @@
+package qb_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestResultColumns(t *testing.T) {
+	qb.RegisterTable(qb.TableMeta{
+		Name:    "columns_dealerships",
+		Columns: []string{"id", "name", "state"},
+	})
+
+	testcases := []struct {
+		name  string
+		query interface{ ResultColumns() []qb.ColumnRef }
+		want  []string
+	}{
+		{
+			name: "fields and expressions",
+			query: qb.Select("vehicles", "id", "v.make", "COUNT(*)", "cost * 2 AS doubled").
+				Expr(qb.As(qb.Select("photos", "COUNT(*)"), "photos")).
+				Expr(qb.Col("vehicles.model")),
+			want: []string{"vehicles.id", "v.make", "COUNT(*)", "doubled", "photos", "vehicles.model"},
+		},
+		{
+			name:  "unknown star",
+			query: qb.Select("vehicles"),
+			want:  []string{"vehicles.*"},
+		},
+		{
+			name:  "registered star",
+			query: qb.Select("columns_dealerships"),
+			want:  []string{"columns_dealerships.id", "columns_dealerships.name", "columns_dealerships.state"},
+		},
+		{
+			name:  "values table",
+			query: qb.SelectFrom(qb.ValuesTable("v", []string{"a", "b"}, [][]interface{}{{1, 2}})),
+			want:  []string{"v.a", "v.b"},
+		},
+		{
+			name:  "table function",
+			query: qb.SelectFrom(qb.Unnest("tag", []string{"a"}).WithOrdinality()),
+			want:  []string{"tag.tag", "tag.ordinality"},
+		},
+		{
+			name: "join",
+			query: qb.Join(
+				qb.Select("employees", "id").Expr(qb.As(qb.Raw("now()"), "fetched_at")),
+				qb.Select("columns_dealerships"),
+			).On("employees.dealership_id", "columns_dealerships.id"),
+			want: []string{"employees.id", "columns_dealerships.id", "columns_dealerships.name", "columns_dealerships.state", "fetched_at"},
+		},
+	}
+	for _, tc := range testcases {
+		var got []string
+		for _, col := range tc.query.ResultColumns() {
+			got = append(got, col.String())
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s:\n\twanted:\n%v\n\tgot:\n%v", tc.name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CopyTo returns a Postgres statement that resolves to the form `COPY (query)
+// TO STDOUT`, which streams the results of a query much faster than reading
+// them row by row. COPY doesn't accept bound parameters, so the values of the
+// query are inlined as literals and the statement has no values of its own. The
+// output is read using the driver's COPY support, such as pgx's
+// PgConn.CopyTo.
+func CopyTo(q Query) CopyQuery {
+	return CopyQuery{
+		Query: q,
+	}
+}
+
+// CopyQuery represents a COPY ... TO STDOUT statement.
+type CopyQuery struct {
+	Query  Query
+	Format string
+	Header bool
+}
+
+// CSV sets the output format to CSV. If header is true, the first line of the
+// output contains the column names.
+func (q CopyQuery) CSV(header bool) CopyQuery {
+	q.Format = "csv"
+	q.Header = header
+	return q
+}
+
+// Build returns a statement of the form `COPY (query) TO STDOUT [WITH (FORMAT
+// format, HEADER)]` with the values of the query inlined.
+func (q CopyQuery) Build() string {
+	stmt := fmt.Sprintf("COPY (%s) TO STDOUT", inline(q.Query))
+	var opts []string
+	if q.Format != "" {
+		opts = append(opts, "FORMAT "+q.Format)
+	}
+	if q.Header {
+		opts = append(opts, "HEADER")
+	}
+	if len(opts) > 0 {
+		stmt += fmt.Sprintf(" WITH (%s)", strings.Join(opts, ", "))
+	}
+	return stmt
+}
+
+func (q CopyQuery) String() string {
+	return Dump(q)
+}
+
+// Values always returns nil for CopyQuery since the values of the query are
+// inlined.
+func (q CopyQuery) Values() []interface{} {
+	return nil
+}
+
+// ToSql is like SelectQuery.ToSql.
+func (q CopyQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(q)
+}
+
+// BuildFor is like SelectQuery.BuildFor.
+func (q CopyQuery) BuildFor(d Dialect) string {
+	return BuildFor(q, d)
+}
+
+// Kind returns KindSelect, since COPY TO only reads.
+func (q CopyQuery) Kind() Kind {
+	return KindSelect
+}
@@ -0,0 +1,43 @@
+package qb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestCopyTo(t *testing.T) {
+	since := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	report := qb.Select("vehicles", "id", "make").Where(qb.And(
+		qb.Equal("make", "O'Reilly"),
+		qb.Or(qb.GreaterEqual("created_at", since), qb.Equal("featured", true)),
+	))
+
+	testcases := []testcase{
+		testcase{
+			name:  "csv with header",
+			query: qb.CopyTo(report).CSV(true),
+			want: output{
+				query: `COPY (SELECT id, make FROM vehicles WHERE (make = 'O''Reilly' AND (created_at >= '2021-03-01T00:00:00Z' OR featured = TRUE))) TO STDOUT WITH (FORMAT csv, HEADER)`,
+			},
+		},
+		testcase{
+			name:  "text",
+			query: qb.CopyTo(qb.Select("vehicles").Where(qb.Less("cost", 1.5)).Sort(qb.OrderByClause{{Field: "id"}})),
+			want: output{
+				query: `COPY (SELECT * FROM vehicles WHERE cost < 1.5 ORDER BY id) TO STDOUT`,
+			},
+		},
+		testcase{
+			name:  "raw",
+			query: qb.CopyTo(qb.Raw("SELECT '?' AS q, ?::bytea, ?", []byte{0xde, 0xad}, nil)),
+			want: output{
+				query: `COPY (SELECT '?' AS q, '\xdead'::bytea, NULL) TO STDOUT`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
@@ -0,0 +1,193 @@
+package qb
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a pagination token can't be decoded, has a
+// bad signature or doesn't match the sort it claims to continue.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor identifies a position in a sorted result set for keyset pagination.
+// Sort is the sort in the form accepted by ParseSort, using API field names,
+// and Values holds the value of each sort field in the last row of the
+// previous page, in the same order.
+type Cursor struct {
+	Sort   string
+	Values []interface{}
+}
+
+// NewCursorCodec returns a codec for cursors over the given fields. If key is
+// not empty, tokens are signed with HMAC-SHA256 so that clients can't forge
+// positions.
+func NewCursorCodec(fields FieldMap, key []byte) CursorCodec {
+	return CursorCodec{
+		Fields: fields,
+		Key:    key,
+	}
+}
+
+// CursorCodec converts cursors to and from opaque tokens that can be handed to
+// API clients, and applies them to queries. Fields is the allowlist of sort
+// fields, as for ParseSort.
+type CursorCodec struct {
+	Fields FieldMap
+	Key    []byte
+}
+
+type cursorPayload struct {
+	Sort   string  `json:"s"`
+	Values []Value `json:"v"`
+}
+
+// Encode returns an opaque token for the cursor. The values keep their types
+// through the round trip, so a time is decoded as a time.Time and an integer
+// as an int64.
+func (c CursorCodec) Encode(cur Cursor) (string, error) {
+	p := cursorPayload{Sort: cur.Sort}
+	for _, v := range cur.Values {
+		tv, err := typedValue(v)
+		if err != nil {
+			return "", fmt.Errorf("qb: encode cursor: %w", err)
+		}
+		p.Values = append(p.Values, tv)
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return "", fmt.Errorf("qb: encode cursor: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	if len(c.Key) > 0 {
+		token += "." + base64.RawURLEncoding.EncodeToString(c.sign(token))
+	}
+	return token, nil
+}
+
+// Decode parses a token returned by Encode. It checks the signature if the
+// codec has a key, and that the sort only uses allowed fields and has one
+// value per field.
+func (c CursorCodec) Decode(token string) (Cursor, error) {
+	payload := token
+	if len(c.Key) > 0 {
+		i := strings.LastIndex(token, ".")
+		if i < 0 {
+			return Cursor{}, fmt.Errorf("qb: %w: missing signature", ErrInvalidCursor)
+		}
+		payload = token[:i]
+		sig, err := base64.RawURLEncoding.DecodeString(token[i+1:])
+		if err != nil || !hmac.Equal(sig, c.sign(payload)) {
+			return Cursor{}, fmt.Errorf("qb: %w: bad signature", ErrInvalidCursor)
+		}
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("qb: %w: %v", ErrInvalidCursor, err)
+	}
+	var p cursorPayload
+	if err := json.Unmarshal(b, &p); err != nil {
+		return Cursor{}, fmt.Errorf("qb: %w: %v", ErrInvalidCursor, err)
+	}
+	cur := Cursor{Sort: p.Sort}
+	for _, v := range p.Values {
+		cur.Values = append(cur.Values, v.Interface())
+	}
+	if _, err := c.order(cur); err != nil {
+		return Cursor{}, err
+	}
+	return cur, nil
+}
+
+// Seek orders the query by the cursor's sort and restricts it to the rows
+// that come after the cursor's position. The condition is combined with any
+// existing WHERE clause using AND and takes the form `(a > ? OR (a = ? AND b
+// > ?))`, with < used for descending fields. A cursor without values returns
+// the first page.
+func (c CursorCodec) Seek(q SelectQuery, cur Cursor) (SelectQuery, error) {
+	order, err := c.order(cur)
+	if err != nil {
+		return SelectQuery{}, err
+	}
+	if len(cur.Values) > 0 {
+		q.WhereClause, q.Vals = andWhere(q.WhereClause, q.Vals, keyset(order, cur.Values))
+	}
+	return q.Sort(order), nil
+}
+
+// order maps the cursor's sort to columns and checks that it matches the
+// values.
+func (c CursorCodec) order(cur Cursor) (OrderByClause, error) {
+	order, err := ParseSort(cur.Sort, c.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("qb: %w: %v", ErrInvalidCursor, err)
+	}
+	if len(order) == 0 {
+		return nil, fmt.Errorf("qb: %w: missing sort", ErrInvalidCursor)
+	}
+	if n := len(cur.Values); n != 0 && n != len(order) {
+		return nil, fmt.Errorf("qb: %w: %d value(s) for %d sort field(s)", ErrInvalidCursor, n, len(order))
+	}
+	return order, nil
+}
+
+func (c CursorCodec) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, c.Key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// keyset returns the condition selecting the rows after vals in the given
+// ordering.
+func keyset(order OrderByClause, vals []interface{}) Query {
+	var cond Query
+	for i := len(order) - 1; i >= 0; i-- {
+		op := ">"
+		if order[i].Dir == Desc {
+			op = "<"
+		}
+		var term Query = ComparisonClause{Op: op, Field: order[i].Field, Value: vals[i]}
+		if cond != nil {
+			term = Or(term, And(Equal(order[i].Field, vals[i]), cond))
+		}
+		cond = term
+	}
+	return cond
+}
+
+// typedValue wraps a plain value in a Value of the matching kind.
+func typedValue(v interface{}) (Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return NullValue(), nil
+	case Value:
+		return v, nil
+	case int:
+		return IntValue(int64(v)), nil
+	case int32:
+		return IntValue(int64(v)), nil
+	case int64:
+		return IntValue(v), nil
+	case uint32:
+		return IntValue(int64(v)), nil
+	case float32:
+		return FloatValue(float64(v)), nil
+	case float64:
+		return FloatValue(v), nil
+	case bool:
+		return BoolValue(v), nil
+	case string:
+		return StringValue(v), nil
+	case time.Time:
+		return TimeValue(v), nil
+	case []byte:
+		return BytesValue(v), nil
+	}
+	return Value{}, fmt.Errorf("unsupported value of type %T", v)
+}
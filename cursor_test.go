@@ -0,0 +1,65 @@
+package qb_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestCursor(t *testing.T) {
+	codec := qb.NewCursorCodec(vehicleFields, []byte("secret"))
+	ts := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	token, err := codec.Encode(qb.Cursor{Sort: "-createdAt,id", Values: []interface{}{ts, 42}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cur, err := codec.Decode(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []interface{}{ts, int64(42)}; !reflect.DeepEqual(cur.Values, want) {
+		t.Errorf("\n\twanted:\n%#v\n\tgot:\n%#v", want, cur.Values)
+	}
+
+	page, err := codec.Seek(qb.Select("vehicles", "id").Where(qb.Equal("make", "Honda")).Limit(10), cur)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(testcase{
+		query: page,
+		want: output{
+			query: `SELECT id FROM vehicles WHERE (make = ? AND (created_at < ? OR (created_at = ? AND id > ?))) ORDER BY created_at DESC, id ASC LIMIT ?`,
+			vals:  []interface{}{"Honda", ts, ts, int64(42), 10},
+		},
+	})(t)
+
+	first, err := codec.Seek(qb.Select("vehicles", "id"), qb.Cursor{Sort: "id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(testcase{
+		query: first,
+		want:  output{query: `SELECT id FROM vehicles ORDER BY id ASC`},
+	})(t)
+
+	bad := []string{
+		token[:len(token)-2],
+		token[:len(token)-44],
+		"not a token",
+	}
+	if forged, err := qb.NewCursorCodec(vehicleFields, []byte("other")).Encode(cur); err == nil {
+		bad = append(bad, forged)
+	}
+	if unknown, err := qb.NewCursorCodec(qb.FieldMap{"vin": "vin"}, []byte("secret")).Encode(qb.Cursor{Sort: "vin", Values: []interface{}{"x"}}); err == nil {
+		bad = append(bad, unknown)
+	}
+	for _, token := range bad {
+		if _, err := codec.Decode(token); !errors.Is(err, qb.ErrInvalidCursor) {
+			t.Errorf("%q: wanted ErrInvalidCursor, got %v", token, err)
+		}
+	}
+}
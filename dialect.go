@@ -0,0 +1,165 @@
+package qb
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Dialect describes how a database expects a statement to be written. Build
+// writes queries with `?` placeholders and otherwise in the form Postgres
+// expects; BuildFor rewrites the placeholders and any clauses that the dialect
+// writes differently, so that the result can be passed straight to the driver
+// without a rebind step.
+type Dialect interface {
+	// Name returns the name of the database, e.g. "postgres".
+	Name() string
+
+	// Placeholder returns the placeholder for the nth value, counting from 1.
+	Placeholder(n int) string
+}
+
+// The dialects supported by qb.
+var (
+	// Postgres numbers placeholders as $1, $2 and so on.
+	Postgres Dialect = numberedDialect{name: "postgres", prefix: "$"}
+
+	// MySQL uses ? for every placeholder.
+	MySQL Dialect = positionalDialect{name: "mysql"}
+
+	// SQLite uses ? for every placeholder.
+	SQLite Dialect = positionalDialect{name: "sqlite"}
+
+	// SQLServer numbers placeholders as @p1, @p2 and so on.
+	SQLServer Dialect = numberedDialect{name: "sqlserver", prefix: "@p"}
+)
+
+type positionalDialect struct {
+	name string
+}
+
+func (d positionalDialect) Name() string {
+	return d.name
+}
+
+func (d positionalDialect) Placeholder(int) string {
+	return "?"
+}
+
+type numberedDialect struct {
+	name   string
+	prefix string
+}
+
+func (d numberedDialect) Name() string {
+	return d.name
+}
+
+func (d numberedDialect) Placeholder(n int) string {
+	return d.prefix + strconv.Itoa(n)
+}
+
+// BuildFor builds q for the dialect and rewrites its placeholders in the form
+// it expects. Clauses that the dialect writes differently, such as LIMIT and
+// OFFSET on SQL Server, are rendered in its own form throughout the statement,
+// including in nested subqueries. Placeholders are numbered in the order of
+// ValuesFor(q, d), which is the same as q.Values() unless a clause binds its
+// values in a different order for the dialect. Placeholders inside quoted
+// strings, quoted identifiers and comments are left alone.
+func BuildFor(q Query, d Dialect) string {
+	sql := forDialect(q, d).Build()
+
+	var sb strings.Builder
+	last, n := 0, 0
+	scanPlaceholders(sql, func(offset int) {
+		n++
+		sb.WriteString(sql[last:offset])
+		sb.WriteString(d.Placeholder(n))
+		last = offset + 1
+	})
+	sb.WriteString(sql[last:])
+	return sb.String()
+}
+
+// ValuesFor returns the values for the statement returned by BuildFor, in
+// order.
+func ValuesFor(q Query, d Dialect) []interface{} {
+	return forDialect(q, d).Values()
+}
+
+// dialected is implemented by queries that are written differently depending
+// on the dialect. withDialect returns a copy of the query, of the same type,
+// that builds for d.
+type dialected interface {
+	withDialect(d Dialect) interface{}
+}
+
+// forDialect returns a copy of q in which every query that implements
+// dialected builds for d.
+func forDialect(q Query, d Dialect) Query {
+	if q == nil {
+		return nil
+	}
+	return rewriteDialect(reflect.ValueOf(q), d).Interface().(Query)
+}
+
+// rewriteDialect copies v, descending into exported struct fields, slices,
+// maps and interfaces to find the queries that implement dialected.
+func rewriteDialect(v reflect.Value, d Dialect) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(rewriteDialect(v.Elem(), d))
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(rewriteDialect(v.Index(i), d))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), rewriteDialect(iter.Value(), d))
+		}
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if f := out.Field(i); f.CanSet() {
+				f.Set(rewriteDialect(v.Field(i), d))
+			}
+		}
+		// Statements keep the values of their WHERE clause in Vals, which
+		// have to follow any change to the clause.
+		where, vals := out.FieldByName("WhereClause"), out.FieldByName("Vals")
+		if where.IsValid() && vals.IsValid() && !where.IsNil() {
+			vals.Set(reflect.ValueOf(where.Interface().(Query).Values()))
+		}
+		if q, ok := out.Interface().(dialected); ok {
+			return reflect.ValueOf(q.withDialect(d))
+		}
+		return out
+	}
+	return v
+}
+
+// dialectName returns the name of d, or the empty string if no dialect was
+// given, in which case queries are built for Postgres.
+func dialectName(d Dialect) string {
+	if d == nil {
+		return ""
+	}
+	return d.Name()
+}
@@ -0,0 +1,141 @@
+package qb_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestBuildFor(t *testing.T) {
+	q := qb.Select("vehicles", "id").
+		Where(qb.And(
+			qb.Equal("make", "Ford"),
+			qb.Equal("dealership_id", qb.Select("dealerships", "id").Where(qb.Equal("state", "NY"))),
+		)).
+		Limit(10)
+
+	testcases := []struct {
+		dialect qb.Dialect
+		want    output
+	}{
+		{qb.Postgres, output{
+			query: `SELECT id FROM vehicles WHERE (make = $1 AND dealership_id = (SELECT id FROM dealerships WHERE state = $2)) LIMIT $3`,
+			vals:  []interface{}{"Ford", "NY", 10},
+		}},
+		{qb.MySQL, output{
+			query: `SELECT id FROM vehicles WHERE (make = ? AND dealership_id = (SELECT id FROM dealerships WHERE state = ?)) LIMIT ?`,
+			vals:  []interface{}{"Ford", "NY", 10},
+		}},
+		{qb.SQLite, output{
+			query: `SELECT id FROM vehicles WHERE (make = ? AND dealership_id = (SELECT id FROM dealerships WHERE state = ?)) LIMIT ?`,
+			vals:  []interface{}{"Ford", "NY", 10},
+		}},
+		{qb.SQLServer, output{
+			query: `SELECT id FROM vehicles WHERE (make = @p1 AND dealership_id = (SELECT id FROM dealerships WHERE state = @p2)) ORDER BY (SELECT NULL) OFFSET @p3 ROWS FETCH NEXT @p4 ROWS ONLY`,
+			vals:  []interface{}{"Ford", "NY", 0, 10},
+		}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.dialect.Name(), testFor(tc.dialect, testcase{query: q, want: tc.want}))
+	}
+}
+
+func TestBuildForSQLServer(t *testing.T) {
+	qb.RegisterTable(qb.TableMeta{
+		Name:    "dialect_vehicles",
+		Columns: []string{"id", "make"},
+	})
+
+	testcases := []testcase{
+		testcase{
+			name:  "page",
+			query: qb.Select("vehicles", "id").OrderBy("id", qb.Asc).Limit(10).Offset(20),
+			want: output{
+				query: `SELECT id FROM vehicles ORDER BY id ASC OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY`,
+				vals:  []interface{}{20, 10},
+			},
+		},
+		testcase{
+			name:  "offset",
+			query: qb.Select("vehicles", "id").OrderBy("id", qb.Asc).Offset(20),
+			want: output{
+				query: `SELECT id FROM vehicles ORDER BY id ASC OFFSET @p1 ROWS`,
+				vals:  []interface{}{20},
+			},
+		},
+		testcase{
+			name:  "nested",
+			query: qb.Select("vehicles", "id").Where(qb.Equal("id", qb.Select("sales", "vehicle_id").Where(qb.Equal("year", 2020)).Limit(1))),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE id = (SELECT vehicle_id FROM sales WHERE year = @p1 ORDER BY (SELECT NULL) OFFSET @p2 ROWS FETCH NEXT @p3 ROWS ONLY)`,
+				vals:  []interface{}{2020, 0, 1},
+			},
+		},
+		testcase{
+			name:  "delete",
+			query: qb.Delete("events").Where(qb.Less("created_at", "2020-01-01")).Sort(qb.OrderByClause{{Field: "created_at"}}).Limit(100),
+			want: output{
+				query: `WITH limited AS (SELECT TOP (@p1) * FROM events WHERE created_at < @p2 ORDER BY created_at) DELETE FROM limited`,
+				vals:  []interface{}{100, "2020-01-01"},
+			},
+		},
+		testcase{
+			name:  "hints",
+			query: qb.Select("vehicles", "id").Hint("SeqScan(vehicles)"),
+			want: output{
+				query: `SELECT id FROM vehicles`,
+			},
+		},
+		testcase{
+			name:  "row hash",
+			query: qb.HashRows("dialect_vehicles", []string{"id"}),
+			want: output{
+				query: `SELECT id, LOWER(CONVERT(VARCHAR(32), HASHBYTES('MD5', CONCAT_WS('|', COALESCE(CAST(dialect_vehicles.id AS VARCHAR(MAX)), '\N'), COALESCE(CAST(dialect_vehicles.make AS VARCHAR(MAX)), '\N'))), 2)) AS row_hash FROM dialect_vehicles ORDER BY id ASC`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, testFor(qb.SQLServer, tc))
+	}
+}
+
+func TestBuildForRowHash(t *testing.T) {
+	q := qb.HashRows("vehicle_options", []string{"id"}, "price")
+
+	testcases := []struct {
+		dialect qb.Dialect
+		want    string
+	}{
+		{qb.Postgres, `SELECT id, md5(concat_ws('|', COALESCE(CAST(price AS TEXT), '\N'))) AS row_hash FROM vehicle_options ORDER BY id ASC`},
+		{qb.MySQL, `SELECT id, MD5(CONCAT_WS('|', COALESCE(CAST(price AS CHAR), '\\N'))) AS row_hash FROM vehicle_options ORDER BY id ASC`},
+		{qb.SQLite, `SELECT id, (COALESCE(CAST(price AS TEXT), '\N')) AS row_hash FROM vehicle_options ORDER BY id ASC`},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.dialect.Name(), testFor(tc.dialect, testcase{query: q, want: output{query: tc.want}}))
+	}
+}
+
+func TestBuildForSkipsQuoted(t *testing.T) {
+	q := qb.Raw(`SELECT '?' AS "?", id FROM vehicles WHERE make = ? -- ?`, "Ford")
+	want := `SELECT '?' AS "?", id FROM vehicles WHERE make = $1 -- ?`
+	if got := qb.BuildFor(q, qb.Postgres); got != want {
+		t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", want, got)
+	}
+}
+
+// testFor is like test, but builds the query for a dialect.
+func testFor(d qb.Dialect, tc testcase) func(t *testing.T) {
+	return func(t *testing.T) {
+		gotQuery := qb.BuildFor(tc.query, d)
+		gotVals := qb.ValuesFor(tc.query, d)
+
+		if gotQuery != tc.want.query {
+			t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", tc.want.query, gotQuery)
+		}
+
+		if !reflect.DeepEqual(gotVals, tc.want.vals) {
+			t.Errorf("\n\twanted:\n%v\n\tgot:\n%v", tc.want.vals, gotVals)
+		}
+	}
+}
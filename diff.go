@@ -0,0 +1,208 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Diff reports the structural differences between two queries, one per line,
+// or an empty string if they are equivalent. Conditions that appear in only one
+// of the queries are reported as added (`+`) or removed (`-`), and comparisons
+// of the same field whose values or operators differ are reported as changed
+// (`~`). The operands of AND and OR are compared regardless of their order or
+// nesting. Each line is prefixed with the location of the difference, and
+// values are shown inline.
+//
+// Diff is intended for test failure messages and for auditing changes to stored
+// filters, so its output is meant to be read rather than parsed.
+func Diff(a, b Query) string {
+	var lines []string
+	diff(&lines, "query", a, b)
+	return strings.Join(lines, "\n")
+}
+
+func diff(lines *[]string, path string, a, b Query) {
+	add := func(format string, args ...interface{}) {
+		*lines = append(*lines, fmt.Sprintf(format, args...))
+	}
+
+	switch {
+	case a == nil && b == nil:
+		return
+	case a == nil:
+		add("+ %s: %s", path, describe(b))
+		return
+	case b == nil:
+		add("- %s: %s", path, describe(a))
+		return
+	case describe(a) == describe(b):
+		return
+	}
+
+	switch a := a.(type) {
+	case BooleanQuery:
+		if b, ok := b.(BooleanQuery); ok && a.Op == b.Op {
+			diffTerms(lines, path, a.Op, a, b)
+			return
+		}
+	case ComparisonClause:
+		if b, ok := b.(ComparisonClause); ok && a.Field == b.Field {
+			sa, aok := a.Value.(Query)
+			sb, bok := b.Value.(Query)
+			if aok && bok && a.Op == b.Op {
+				diff(lines, fmt.Sprintf("%s.comparison(%q)", path, a.Field), sa, sb)
+				return
+			}
+		}
+	case SelectQuery:
+		if b, ok := b.(SelectQuery); ok {
+			diffSelect(lines, path, a, b)
+			return
+		}
+	case DeleteQuery:
+		if b, ok := b.(DeleteQuery); ok {
+			diffString(lines, path+".table", a.Table, b.Table)
+			diff(lines, path+".where", a.WhereClause, b.WhereClause)
+			diffString(lines, path+".order by", a.Ordering.Build(), b.Ordering.Build())
+			diffString(lines, path+".limit", dumpLimit(a.LimitRows), dumpLimit(b.LimitRows))
+			return
+		}
+	case JoinQuery:
+		if b, ok := b.(JoinQuery); ok {
+			diff(lines, path+".join[0]", a.Query1, b.Query1)
+			diff(lines, path+".join[1]", a.Query2, b.Query2)
+			diff(lines, path+".on", a.OnClause, b.OnClause)
+			diffString(lines, path+".type", a.JoinType, b.JoinType)
+			for i := 0; i < len(a.Joins) || i < len(b.Joins); i++ {
+				p := fmt.Sprintf("%s.join[%d]", path, i+2)
+				switch {
+				case i >= len(b.Joins):
+					add("- %s: %s", p, describe(a.Joins[i].Query))
+				case i >= len(a.Joins):
+					add("+ %s: %s", p, describe(b.Joins[i].Query))
+				default:
+					diff(lines, p, a.Joins[i].Query, b.Joins[i].Query)
+					diff(lines, p+".on", a.Joins[i].OnClause, b.Joins[i].OnClause)
+					diffString(lines, p+".type", a.Joins[i].JoinType, b.Joins[i].JoinType)
+				}
+			}
+			diff(lines, path+".where", a.WhereClause, b.WhereClause)
+			return
+		}
+	}
+	add("~ %s: %s -> %s", path, describe(a), describe(b))
+}
+
+func diffSelect(lines *[]string, path string, a, b SelectQuery) {
+	diffString(lines, path+".table", a.Table, b.Table)
+	diffString(lines, path+".fields", strings.Join(a.Fields, ", "), strings.Join(b.Fields, ", "))
+	diff(lines, path+".from", a.Source, b.Source)
+	for i := 0; i < len(a.Exprs) || i < len(b.Exprs); i++ {
+		var ea, eb Query
+		if i < len(a.Exprs) {
+			ea = a.Exprs[i]
+		}
+		if i < len(b.Exprs) {
+			eb = b.Exprs[i]
+		}
+		diff(lines, fmt.Sprintf("%s.expr[%d]", path, i), ea, eb)
+	}
+	diff(lines, path+".where", a.WhereClause, b.WhereClause)
+	diffString(lines, path+".order by", a.Ordering.Build(), b.Ordering.Build())
+	diffString(lines, path+".limit", dumpLimit(a.LimitRows), dumpLimit(b.LimitRows))
+	diffString(lines, path+".offset", dumpOffset(a.OffsetRows), dumpOffset(b.OffsetRows))
+	// Anything else, such as hints or partitions, is reported as a change to
+	// the query as a whole.
+	if describe(diffRest(a)) != describe(diffRest(b)) {
+		*lines = append(*lines, fmt.Sprintf("~ %s: %s -> %s", path, describe(a), describe(b)))
+	}
+}
+
+// diffRest clears the parts of q that diffSelect compares individually.
+func diffRest(q SelectQuery) SelectQuery {
+	q.Table = ""
+	q.Fields = nil
+	q.Source = nil
+	q.Exprs = nil
+	q.WhereClause = nil
+	q.Vals = nil
+	q.Ordering = nil
+	q.LimitRows = 0
+	q.OffsetRows = 0
+	return q
+}
+
+// diffTerms compares the operands of two chains of the same boolean operator
+// as sets. Comparisons that only differ in their operator or value are paired
+// up and reported as changes.
+func diffTerms(lines *[]string, path, op string, a, b BooleanQuery) {
+	removed := flattenTerms(op, a)
+	added := flattenTerms(op, b)
+
+	for i := 0; i < len(removed); i++ {
+		for j := 0; j < len(added); j++ {
+			if describe(removed[i]) == describe(added[j]) {
+				removed = append(removed[:i], removed[i+1:]...)
+				added = append(added[:j], added[j+1:]...)
+				i--
+				break
+			}
+		}
+	}
+	for i := 0; i < len(removed); i++ {
+		ca, ok := removed[i].(ComparisonClause)
+		if !ok {
+			continue
+		}
+		for j := 0; j < len(added); j++ {
+			if cb, ok := added[j].(ComparisonClause); ok && ca.Field == cb.Field {
+				diff(lines, path, ca, cb)
+				removed = append(removed[:i], removed[i+1:]...)
+				added = append(added[:j], added[j+1:]...)
+				i--
+				break
+			}
+		}
+	}
+
+	for _, t := range removed {
+		*lines = append(*lines, fmt.Sprintf("- %s: %s", path, describe(t)))
+	}
+	for _, t := range added {
+		*lines = append(*lines, fmt.Sprintf("+ %s: %s", path, describe(t)))
+	}
+}
+
+func flattenTerms(op string, q Query) []Query {
+	if b, ok := q.(BooleanQuery); ok && b.Op == op {
+		return append(flattenTerms(op, b.Comparison1), flattenTerms(op, b.Comparison2)...)
+	}
+	return []Query{q}
+}
+
+func diffString(lines *[]string, path, a, b string) {
+	if a != b {
+		*lines = append(*lines, fmt.Sprintf("~ %s: %q -> %q", path, a, b))
+	}
+}
+
+// describe builds q with its values substituted for their placeholders.
+func describe(q Query) string {
+	sql := q.Build()
+	vals := q.Values()
+
+	var sb strings.Builder
+	last, n := 0, 0
+	scanPlaceholders(sql, func(offset int) {
+		sb.WriteString(sql[last:offset])
+		if n < len(vals) {
+			sb.WriteString(dumpValue(vals[n]))
+		} else {
+			sb.WriteString("?")
+		}
+		n++
+		last = offset + 1
+	})
+	sb.WriteString(sql[last:])
+	return sb.String()
+}
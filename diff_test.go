@@ -0,0 +1,78 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestDiff(t *testing.T) {
+	base := qb.Select("vehicles", "id").Where(qb.And(
+		qb.Equal("make", "Honda"),
+		qb.And(qb.Less("cost", 20000), qb.Greater("year", 2015)),
+	))
+
+	testcases := []struct {
+		name string
+		a, b qb.Query
+		want string
+	}{
+		{
+			name: "identical",
+			a:    base,
+			b:    qb.Select("vehicles", "id").Where(qb.And(qb.And(qb.Equal("make", "Honda"), qb.Less("cost", 20000)), qb.Greater("year", 2015))),
+			want: ``,
+		},
+		{
+			name: "changed value",
+			a:    base,
+			b: qb.Select("vehicles", "id").Where(qb.And(
+				qb.Greater("year", 2015),
+				qb.And(qb.Equal("make", "Honda"), qb.Less("cost", 30000)),
+			)),
+			want: `~ query.where: cost < 20000 -> cost < 30000`,
+		},
+		{
+			name: "added and removed",
+			a:    base,
+			b: qb.Select("vehicles", "id", "make").Where(qb.And(
+				qb.Equal("make", "Honda"),
+				qb.And(qb.Less("cost", 20000), qb.Equal("color", "red")),
+			)),
+			want: "~ query.fields: \"id\" -> \"id, make\"\n" +
+				"- query.where: year > 2015\n" +
+				"+ query.where: color = \"red\"",
+		},
+		{
+			name: "subquery",
+			a:    qb.Delete("photos").Where(qb.Equal("vehicle_id", qb.Select("vehicles", "id").Where(qb.Equal("make", "Honda")))),
+			b:    qb.Delete("photos").Where(qb.Equal("vehicle_id", qb.Select("vehicles", "id").Where(qb.Equal("make", "Toyota")))),
+			want: `~ query.where.comparison("vehicle_id").where: make = "Honda" -> make = "Toyota"`,
+		},
+		{
+			name: "missing where",
+			a:    qb.Select("vehicles"),
+			b:    qb.Select("vehicles").Where(qb.Equal("make", "Honda")),
+			want: `+ query.where: make = "Honda"`,
+		},
+		{
+			name: "other change",
+			a:    qb.Select("vehicles"),
+			b:    qb.Select("vehicles").Hint("SeqScan(vehicles)"),
+			want: `~ query: SELECT * FROM vehicles -> SELECT /*+ SeqScan(vehicles) */ * FROM vehicles`,
+		},
+		{
+			name: "different types",
+			a:    qb.Equal("make", "Honda"),
+			b:    qb.Or(qb.Equal("make", "Honda"), qb.Equal("make", "Acura")),
+			want: `~ query: make = "Honda" -> (make = "Honda" OR make = "Acura")`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := qb.Diff(tc.a, tc.b); got != tc.want {
+				t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", tc.want, got)
+			}
+		})
+	}
+}
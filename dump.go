@@ -0,0 +1,214 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dump returns a human-readable, indented description of a query tree. Each
+// line shows the type of a clause along with its operator, fields and values,
+// and subclauses are nested beneath their parent. It is intended for debugging
+// and test failure output rather than for parsing.
+func Dump(q Query) string {
+	var b strings.Builder
+	dump(&b, 0, "", q)
+	return b.String()
+}
+
+func dump(b *strings.Builder, depth int, label string, q Query) {
+	b.WriteString(strings.Repeat("  ", depth))
+	if label != "" {
+		b.WriteString(label + ": ")
+	}
+	if q == nil {
+		b.WriteString("<nil>\n")
+		return
+	}
+
+	line := func(format string, args ...interface{}) {
+		fmt.Fprintf(b, format+"\n", args...)
+	}
+	child := func(label string, q Query) {
+		dump(b, depth+1, label, q)
+	}
+
+	switch q := q.(type) {
+	case InClause:
+		line("InClause field=%s%s", q.Field, dumpValues(q.Vals))
+	case NullClause:
+		line("NullClause field=%s not=%t", q.Field, q.Not)
+	case BetweenClause:
+		line("BetweenClause field=%s not=%t low=%s high=%s", q.Field, q.Not, dumpValue(q.Low), dumpValue(q.High))
+	case ComparisonClause:
+		if sub, ok := q.Value.(Query); ok {
+			line("ComparisonClause field=%s op=%s", q.Field, q.Op)
+			child("value", sub)
+		} else {
+			line("ComparisonClause field=%s op=%s value=%s", q.Field, q.Op, dumpValue(q.Value))
+		}
+	case Column:
+		line("Column name=%s", string(q))
+	case BoolClause:
+		line("BoolClause value=%t", bool(q))
+	case AliasClause:
+		line("AliasClause alias=%s", q.Alias)
+		child("query", q.Query)
+	case BooleanQuery:
+		line("BooleanQuery op=%s", q.Op)
+		child("[0]", q.Comparison1)
+		child("[1]", q.Comparison2)
+	case NotClause:
+		line("NotClause")
+		child("query", q.Query)
+	case Filter:
+		line("Filter name=%s", q.Name)
+		child("cond", q.Cond)
+	case DeleteQuery:
+		line("DeleteQuery table=%s%s", q.Table, dumpLimit(q.LimitRows))
+		dumpOptional(child, "where", q.WhereClause)
+		dumpOrdering(child, q.Ordering)
+	case UpdateQuery:
+		line("UpdateQuery table=%s", q.Table)
+		for i, a := range q.Assignments {
+			child(fmt.Sprintf("set[%d]", i), a)
+		}
+		dumpOptional(child, "where", q.WhereClause)
+	case SelectQuery:
+		dumpSelect(line, child, q)
+	case On:
+		line("On %s = %s", q.Field1, q.Field2)
+	case JoinQuery:
+		if q.JoinType != "" {
+			line("JoinQuery type=%s", q.JoinType)
+		} else {
+			line("JoinQuery")
+		}
+		child("[0]", q.Query1)
+		child("[1]", q.Query2)
+		dumpOptional(child, "on", q.OnClause)
+		for i, j := range q.Joins {
+			child(fmt.Sprintf("[%d] %s", i+2, j.JoinType), j.Query)
+			dumpOptional(child, fmt.Sprintf("[%d] on", i+2), j.OnClause)
+		}
+		dumpOptional(child, "where", q.WhereClause)
+	case OrderByClause:
+		line("OrderByClause")
+		for i, o := range q {
+			child(fmt.Sprintf("[%d]", i), o)
+		}
+	case Order:
+		opts := ""
+		if q.Collation != "" {
+			opts += " collate=" + q.Collation
+		}
+		if q.NullsLast {
+			opts += " nulls=last"
+		}
+		if q.Expr != nil {
+			line("Order dir=%s%s", q.Dir, opts)
+			child("expr", q.Expr)
+		} else {
+			line("Order field=%s dir=%s%s", q.Field, q.Dir, opts)
+		}
+	case TemplateQuery:
+		line("TemplateQuery sql=%q", q.SQL)
+		for _, m := range holePattern.FindAllStringSubmatch(q.SQL, -1) {
+			if hole, ok := q.hole(m[1]); ok {
+				child(m[1], hole)
+			}
+		}
+	case keywordClause:
+		line("%s", q.Keyword)
+		child("query", q.Query)
+	case RawQuery:
+		line("RawQuery sql=%q%s", q.SQL, dumpValues(q.Vals))
+	case CreateTableAsQuery:
+		line("CreateTableAsQuery table=%s temporary=%t", q.Name, q.Temporary)
+		child("as", q.Query)
+	case CopyQuery:
+		line("CopyQuery format=%s header=%t", q.Format, q.Header)
+		child("query", q.Query)
+	case ExplainQuery:
+		line("ExplainQuery analyze=%t", q.Analyze)
+		child("query", q.Query)
+	case AnnotatedQuery:
+		line("AnnotatedQuery comment=%q", q.Comment)
+		child("query", q.Query)
+	case AuditQuery:
+		line("AuditQuery history=%s", q.HistoryTable)
+		dumpOptional(child, "query", q.Query)
+	case CaptureQuery:
+		line("CaptureQuery returning=[%s]", strings.Join(q.PrimaryKey, ", "))
+		dumpOptional(child, "query", q.Query)
+	default:
+		name := strings.TrimPrefix(fmt.Sprintf("%T", q), "qb.")
+		line("%s sql=%q%s", name, q.Build(), dumpValues(q.Values()))
+	}
+}
+
+func dumpSelect(line func(string, ...interface{}), child func(string, Query), q SelectQuery) {
+	fields := "*"
+	if len(q.Fields) > 0 {
+		fields = strings.Join(q.Fields, ", ")
+	}
+	table := q.Table
+	if table == "" {
+		table = "<source>"
+	}
+	groups := ""
+	if len(q.Groups) > 0 {
+		groups = fmt.Sprintf(" group by=[%s]", strings.Join(q.Groups, ", "))
+	}
+	line("SelectQuery table=%s fields=[%s]%s%s%s", table, fields, groups, dumpLimit(q.LimitRows), dumpOffset(q.OffsetRows))
+	dumpOptional(child, "from", q.Source)
+	for i, expr := range q.Exprs {
+		child(fmt.Sprintf("expr[%d]", i), expr)
+	}
+	dumpOptional(child, "where", q.WhereClause)
+	dumpOptional(child, "having", q.HavingClause)
+	dumpOrdering(child, q.Ordering)
+}
+
+func dumpOptional(child func(string, Query), label string, q Query) {
+	if q != nil {
+		child(label, q)
+	}
+}
+
+func dumpOrdering(child func(string, Query), o OrderByClause) {
+	if len(o) > 0 {
+		child("order by", o)
+	}
+}
+
+func dumpLimit(n int) string {
+	if n > 0 {
+		return fmt.Sprintf(" limit=%d", n)
+	}
+	return ""
+}
+
+func dumpOffset(n int) string {
+	if n > 0 {
+		return fmt.Sprintf(" offset=%d", n)
+	}
+	return ""
+}
+
+func dumpValues(vals []interface{}) string {
+	if len(vals) == 0 {
+		return ""
+	}
+	s := make([]string, 0, len(vals))
+	for _, v := range vals {
+		s = append(s, dumpValue(v))
+	}
+	return " values=[" + strings.Join(s, ", ") + "]"
+}
+
+func dumpValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,47 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestDump(t *testing.T) {
+	q := qb.Select("photos", "url").
+		Where(qb.And(
+			qb.Equal("public", true),
+			qb.Equal("vehicle_id", qb.Select("vehicles", "id").Where(qb.Equal("make", "Honda"))),
+		)).
+		Sort(qb.OrderByClause{{Field: "created_at", Dir: qb.Desc}})
+
+	want := `SelectQuery table=photos fields=[url]
+  where: BooleanQuery op=AND
+    [0]: ComparisonClause field=public op== value=true
+    [1]: ComparisonClause field=vehicle_id op==
+      value: SelectQuery table=vehicles fields=[id]
+        where: ComparisonClause field=make op== value="Honda"
+  order by: OrderByClause
+    [0]: Order field=created_at dir=DESC
+`
+	if got := q.String(); got != want {
+		t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", want, got)
+	}
+
+	join := qb.Join(
+		qb.Select("employees", "id"),
+		qb.Select("dealerships"),
+	).On("employees.dealership_id", "dealerships.id")
+	want = `JoinQuery
+  [0]: SelectQuery table=employees fields=[id]
+  [1]: SelectQuery table=dealerships fields=[*]
+  on: On employees.dealership_id = dealerships.id
+`
+	if got := qb.Dump(join); got != want {
+		t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", want, got)
+	}
+
+	want = "RawQuery sql=\"SELECT ?\" values=[\"x\"]\n"
+	if got := qb.Dump(qb.Raw("SELECT ?", "x")); got != want {
+		t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", want, got)
+	}
+}
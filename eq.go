@@ -0,0 +1,53 @@
+package qb
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Eq returns a condition matching every column in the map to its value,
+// combined with AND in column order, e.g. `(make IN (?, ?) AND state = ?)`. A
+// slice or array value (other than []byte) matches any of its elements with
+// IN, or nothing at all if it is empty, and nil matches with `IS NULL`. An
+// empty map returns TRUE.
+func Eq(conds map[string]interface{}) Query {
+	columns := make([]string, 0, len(conds))
+	for column := range conds {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	var where Query
+	for _, column := range columns {
+		cond := eqCondition(column, conds[column])
+		if where == nil {
+			where = cond
+		} else {
+			where = And(where, cond)
+		}
+	}
+	if where == nil {
+		return Bool(true)
+	}
+	return where
+}
+
+func eqCondition(column string, v interface{}) Query {
+	if v == nil {
+		return IsNull(column)
+	}
+	if _, ok := v.([]byte); ok {
+		return Equal(column, v)
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		if rv.Len() == 0 {
+			return Bool(false)
+		}
+		vals := make([]interface{}, rv.Len())
+		for i := range vals {
+			vals[i] = rv.Index(i).Interface()
+		}
+		return In(column, vals...)
+	}
+	return Equal(column, v)
+}
@@ -0,0 +1,51 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestEq(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name: "equality and in",
+			query: qb.Select("vehicles", "id").Where(qb.Eq(map[string]interface{}{
+				"state": "NY",
+				"make":  []string{"Honda", "Toyota"},
+			})),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE (make IN (?, ?) AND state = ?)`,
+				vals:  []interface{}{"Honda", "Toyota", "NY"},
+			},
+		},
+		testcase{
+			name:  "null",
+			query: qb.Select("vehicles", "id").Where(qb.Eq(map[string]interface{}{"sold_at": nil})),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE sold_at IS NULL`,
+			},
+		},
+		testcase{
+			name: "empty slice",
+			query: qb.Select("vehicles", "id").Where(qb.Eq(map[string]interface{}{
+				"state": "NY",
+				"make":  []string{},
+			})),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE (FALSE AND state = ?)`,
+				vals:  []interface{}{"NY"},
+			},
+		},
+		testcase{
+			name:  "empty",
+			query: qb.Delete("vehicles").Where(qb.Eq(nil)),
+			want: output{
+				query: `DELETE FROM vehicles WHERE TRUE`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
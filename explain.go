@@ -0,0 +1,58 @@
+package qb
+
+// Explain returns a query that resolves to the form `EXPLAIN query`, asking the
+// database for its plan instead of running the query.
+func Explain(q Query) ExplainQuery {
+	return ExplainQuery{
+		Query: q,
+	}
+}
+
+// ExplainQuery represents a query that resolves to the general form `EXPLAIN
+// [ANALYZE] query`.
+type ExplainQuery struct {
+	Query   Query
+	Analyze bool
+}
+
+// Build returns a query string of the general form `EXPLAIN [ANALYZE] query`.
+func (q ExplainQuery) Build() string {
+	if q.Analyze {
+		return "EXPLAIN ANALYZE " + q.Query.Build()
+	}
+	return "EXPLAIN " + q.Query.Build()
+}
+
+func (q ExplainQuery) String() string {
+	return q.Build()
+}
+
+// Values returns the values for the explained query.
+func (q ExplainQuery) Values() []interface{} {
+	return q.Query.Values()
+}
+
+// ToSql is like SelectQuery.ToSql.
+func (q ExplainQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(q)
+}
+
+// BuildFor is like SelectQuery.BuildFor.
+func (q ExplainQuery) BuildFor(d Dialect) string {
+	return BuildFor(q, d)
+}
+
+// Kind returns the kind of the explained query. Without ANALYZE the query
+// isn't executed, but it is still classified by what it would do.
+func (q ExplainQuery) Kind() Kind {
+	return KindOf(q.Query)
+}
+
+// WithAnalyze makes the database run the query and report actual timings
+// alongside its estimates. The query is really executed, so this should not be
+// used with statements that modify data outside of a transaction that will be
+// rolled back.
+func (q ExplainQuery) WithAnalyze() ExplainQuery {
+	q.Analyze = true
+	return q
+}
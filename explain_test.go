@@ -0,0 +1,33 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestExplainQuery(t *testing.T) {
+	q := qb.Select("vehicles", "id").Where(qb.Equal("make", "Honda"))
+
+	testcases := []testcase{
+		testcase{
+			name:  "explain",
+			query: qb.Explain(q),
+			want: output{
+				query: `EXPLAIN SELECT id FROM vehicles WHERE make = ?`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name:  "explain analyze",
+			query: qb.Explain(q).WithAnalyze(),
+			want: output{
+				query: `EXPLAIN ANALYZE SELECT id FROM vehicles WHERE make = ?`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
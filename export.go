@@ -0,0 +1,113 @@
+package qb
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Rows is the subset of *sql.Rows used by the export helpers.
+type Rows interface {
+	Next() bool
+	Scan(dest ...interface{}) error
+	Err() error
+}
+
+// Shaped is implemented by queries that know the columns of their result, such
+// as SelectQuery and JoinQuery.
+type Shaped interface {
+	Query
+	ResultColumns() []ColumnRef
+}
+
+// WriteCSV streams rows returned by executing q to w as CSV, with a header row
+// taken from the query's ResultColumns. NULLs are written as empty fields,
+// byte slices as text and times in RFC 3339 format. The query must have an
+// explicit field list or a table with registered Columns, since the header
+// can't be determined for an unexpanded `*`. The rows are not closed.
+func WriteCSV(w io.Writer, q Shaped, rows Rows) error {
+	names, err := exportNames(q)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(names); err != nil {
+		return err
+	}
+	record := make([]string, len(names))
+	err = scanRows(rows, len(names), func(vals []interface{}) error {
+		for i, v := range vals {
+			record[i] = formatCSV(v)
+		}
+		return cw.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSONL streams rows returned by executing q to w as JSON lines, with one
+// object per row keyed by the names of the query's ResultColumns. Byte slices
+// are written as strings. The same restrictions as for WriteCSV apply.
+func WriteJSONL(w io.Writer, q Shaped, rows Rows) error {
+	names, err := exportNames(q)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	return scanRows(rows, len(names), func(vals []interface{}) error {
+		obj := make(map[string]interface{}, len(names))
+		for i, v := range vals {
+			if b, ok := v.([]byte); ok {
+				v = string(b)
+			}
+			obj[names[i]] = v
+		}
+		return enc.Encode(obj)
+	})
+}
+
+func exportNames(q Shaped) ([]string, error) {
+	cols := q.ResultColumns()
+	names := make([]string, 0, len(cols))
+	for _, col := range cols {
+		if col.Name == "*" {
+			return nil, fmt.Errorf("qb: can't export %s: columns of %s are unknown", q.Build(), col.Table)
+		}
+		names = append(names, col.Name)
+	}
+	return names, nil
+}
+
+func scanRows(rows Rows, n int, fn func([]interface{}) error) error {
+	vals := make([]interface{}, n)
+	dest := make([]interface{}, n)
+	for i := range vals {
+		dest[i] = &vals[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		if err := fn(vals); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func formatCSV(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
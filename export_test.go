@@ -0,0 +1,80 @@
+package qb_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haleyrc/qb"
+)
+
+// fakeRows implements qb.Rows over a fixed set of rows.
+type fakeRows struct {
+	rows [][]interface{}
+	i    int
+	err  error
+}
+
+func (r *fakeRows) Next() bool {
+	r.i++
+	return r.i <= len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	row := r.rows[r.i-1]
+	if len(dest) != len(row) {
+		return errors.New("wrong number of destinations")
+	}
+	for i, v := range row {
+		*dest[i].(*interface{}) = v
+	}
+	return nil
+}
+
+func (r *fakeRows) Err() error {
+	return r.err
+}
+
+func TestWriteCSV(t *testing.T) {
+	q := qb.Select("vehicles", "id", "make").Expr(qb.As(qb.Raw("now()"), "checked_at"))
+	checked := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	rows := &fakeRows{rows: [][]interface{}{
+		{int64(1), []byte("Honda"), checked},
+		{int64(2), "Acura, Inc.", nil},
+	}}
+
+	var buf bytes.Buffer
+	if err := qb.WriteCSV(&buf, q, rows); err != nil {
+		t.Fatal(err)
+	}
+	want := "id,make,checked_at\n1,Honda,2021-03-01T12:00:00Z\n2,\"Acura, Inc.\",\n"
+	if got := buf.String(); got != want {
+		t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", want, got)
+	}
+
+	if err := qb.WriteCSV(&buf, qb.Select("vehicles"), &fakeRows{}); err == nil {
+		t.Error("expected an error exporting an unexpanded *")
+	}
+	failed := &fakeRows{err: errors.New("connection reset")}
+	if err := qb.WriteCSV(&buf, q, failed); err != failed.err {
+		t.Errorf("expected the rows error, got %v", err)
+	}
+}
+
+func TestWriteJSONL(t *testing.T) {
+	q := qb.Select("vehicles", "id", "make")
+	rows := &fakeRows{rows: [][]interface{}{
+		{int64(1), []byte("Honda")},
+		{int64(2), nil},
+	}}
+
+	var buf bytes.Buffer
+	if err := qb.WriteJSONL(&buf, q, rows); err != nil {
+		t.Fatal(err)
+	}
+	want := "{\"id\":1,\"make\":\"Honda\"}\n{\"id\":2,\"make\":null}\n"
+	if got := buf.String(); got != want {
+		t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", want, got)
+	}
+}
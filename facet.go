@@ -0,0 +1,127 @@
+package qb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FacetCount is the number of rows with a single value of a facet column.
+type FacetCount struct {
+	Value interface{}
+	Count int64
+}
+
+// FacetQueries returns one query per facet column counting the rows matched by
+// base for each value of the column, of the form `SELECT column, COUNT(*) FROM
+// table WHERE ... GROUP BY column ORDER BY COUNT(*) DESC, column`. The field
+// list, ordering and limit of base are replaced; only its table and
+// conditions are kept. Read the results with ScanFacet.
+func FacetQueries(base SelectQuery, columns ...string) []SelectQuery {
+	base = facetBase(base)
+	queries := make([]SelectQuery, 0, len(columns))
+	for _, col := range columns {
+		q := base
+		q.Fields = []string{col, "COUNT(*)"}
+		q.Groups = []string{col}
+		q.Ordering = OrderByClause{{Field: "COUNT(*)", Dir: Desc}, {Field: col}}
+		queries = append(queries, q)
+	}
+	return queries
+}
+
+// FacetQuery is like FacetQueries, but counts every facet in a single query
+// using GROUPING SETS, of the form `SELECT a, b, COUNT(*), GROUPING(a, b) FROM
+// table WHERE ... GROUP BY GROUPING SETS ((a), (b))`. The extra GROUPING column
+// tells the facets apart, so NULL values are reported correctly. Read the
+// results with ScanFacets. Requires Postgres or another database with
+// GROUPING SETS.
+func FacetQuery(base SelectQuery, columns ...string) SelectQuery {
+	q := facetBase(base)
+	sets := make([]string, 0, len(columns))
+	for _, col := range columns {
+		sets = append(sets, "("+col+")")
+	}
+	q.Fields = append(append([]string{}, columns...), "COUNT(*)", fmt.Sprintf("GROUPING(%s)", strings.Join(columns, ", ")))
+	q.Groups = []string{fmt.Sprintf("GROUPING SETS (%s)", strings.Join(sets, ", "))}
+	return q
+}
+
+// facetBase strips everything but the source and conditions from base. The
+// default scopes are applied first so that they still filter the counts, but
+// the default ordering is not.
+func facetBase(base SelectQuery) SelectQuery {
+	base = base.scoped()
+	base.Exprs = nil
+	base.Ordering = nil
+	base.LimitRows = 0
+	base.OffsetRows = 0
+	return base
+}
+
+// ScanFacet reads the results of one of the queries returned by FacetQueries.
+// The rows are not closed.
+func ScanFacet(rows Rows) ([]FacetCount, error) {
+	var counts []FacetCount
+	err := scanRows(rows, 2, func(vals []interface{}) error {
+		n, err := facetInt(vals[1])
+		if err != nil {
+			return err
+		}
+		counts = append(counts, FacetCount{Value: facetValue(vals[0]), Count: n})
+		return nil
+	})
+	return counts, err
+}
+
+// ScanFacets reads the results of a query returned by FacetQuery, keyed by
+// column. The columns must be given in the same order as for FacetQuery. The
+// rows are not closed.
+func ScanFacets(rows Rows, columns ...string) (map[string][]FacetCount, error) {
+	n := len(columns)
+	facets := make(map[string][]FacetCount, n)
+	err := scanRows(rows, n+2, func(vals []interface{}) error {
+		count, err := facetInt(vals[n])
+		if err != nil {
+			return err
+		}
+		mask, err := facetInt(vals[n+1])
+		if err != nil {
+			return err
+		}
+		// GROUPING sets a bit for every column that isn't grouped, with the
+		// first column in the most significant position.
+		for i, col := range columns {
+			if mask == int64(1<<n-1)&^(1<<(n-1-i)) {
+				facets[col] = append(facets[col], FacetCount{Value: facetValue(vals[i]), Count: count})
+				return nil
+			}
+		}
+		return fmt.Errorf("qb: unexpected grouping %d in facet results", mask)
+	})
+	return facets, err
+}
+
+// facetValue copies byte slices, which the driver may reuse, into strings.
+func facetValue(v interface{}) interface{} {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return v
+}
+
+func facetInt(v interface{}) (int64, error) {
+	switch v := v.(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case []byte:
+		return strconv.ParseInt(string(v), 10, 64)
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	}
+	return 0, fmt.Errorf("qb: unexpected count of type %T in facet results", v)
+}
@@ -0,0 +1,59 @@
+package qb_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestFacets(t *testing.T) {
+	base := qb.Select("vehicles", "id").Where(qb.Less("cost", 10000)).OrderBy("id", qb.Asc).Limit(20)
+
+	queries := qb.FacetQueries(base, "make", "state")
+	if len(queries) != 2 {
+		t.Fatalf("wanted 2 queries, got %d", len(queries))
+	}
+	test(testcase{
+		query: queries[1],
+		want: output{
+			query: `SELECT state, COUNT(*) FROM vehicles WHERE cost < ? GROUP BY state ORDER BY COUNT(*) DESC, state`,
+			vals:  []interface{}{10000},
+		},
+	})(t)
+	test(testcase{
+		query: qb.FacetQuery(base, "make", "state"),
+		want: output{
+			query: `SELECT make, state, COUNT(*), GROUPING(make, state) FROM vehicles WHERE cost < ? GROUP BY GROUPING SETS ((make), (state))`,
+			vals:  []interface{}{10000},
+		},
+	})(t)
+
+	counts, err := qb.ScanFacet(&fakeRows{rows: [][]interface{}{
+		{[]byte("Honda"), int64(3)},
+		{nil, int64(1)},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []qb.FacetCount{{Value: "Honda", Count: 3}, {Value: nil, Count: 1}}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("\n\twanted:\n%#v\n\tgot:\n%#v", want, counts)
+	}
+
+	facets, err := qb.ScanFacets(&fakeRows{rows: [][]interface{}{
+		{"Honda", nil, int64(3), int64(1)},
+		{"Acura", nil, int64(2), int64(1)},
+		{nil, "NY", int64(4), int64(2)},
+		{nil, nil, int64(1), int64(2)},
+	}}, "make", "state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]qb.FacetCount{
+		"make":  {{Value: "Honda", Count: 3}, {Value: "Acura", Count: 2}},
+		"state": {{Value: "NY", Count: 4}, {Value: nil, Count: 1}},
+	}
+	if !reflect.DeepEqual(facets, want) {
+		t.Errorf("\n\twanted:\n%#v\n\tgot:\n%#v", want, facets)
+	}
+}
@@ -0,0 +1,125 @@
+package qb
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FieldMap maps the field names exposed by an API to the columns that back
+// them. It doubles as an allowlist: helpers that accept user-supplied field
+// names reject anything that isn't in the map.
+type FieldMap map[string]string
+
+// Column returns the column for the named API field, or an error if the field
+// isn't allowed.
+func (m FieldMap) Column(field string) (string, error) {
+	column, ok := m[field]
+	if !ok {
+		return "", fmt.Errorf("qb: unknown field %q", field)
+	}
+	return column, nil
+}
+
+// GraphQLSelect maps a GraphQL selection set and its arguments to a SELECT
+// against the given table. Only the selected fields are fetched, so resolvers
+// don't over-fetch columns, and an error is returned if nothing is selected
+// rather than falling back to `*`. Each argument is matched for equality
+// against its column with the conditions combined using AND, except for the
+// paging arguments:
+//
+//   - `orderBy` sorts the results, using the syntax of ParseSort, e.g.
+//     `-createdAt,id`.
+//   - `first` or `limit` caps the number of rows returned.
+//
+// The selection, the filter arguments and the fields of orderBy must all be
+// present in allowed.
+//
+// The selection is the list of field names requested by the client, as
+// collected from the resolver's field info by the GraphQL library in use.
+func GraphQLSelect(table string, allowed FieldMap, selection []string, args map[string]interface{}) (SelectQuery, error) {
+	if len(selection) == 0 {
+		return SelectQuery{}, fmt.Errorf("qb: empty selection for %s", table)
+	}
+	fields := make([]string, 0, len(selection))
+	for _, field := range selection {
+		column, err := allowed.Column(field)
+		if err != nil {
+			return SelectQuery{}, err
+		}
+		fields = append(fields, column)
+	}
+	q := Select(table, fields...)
+
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if args["first"] != nil && args["limit"] != nil {
+		return SelectQuery{}, fmt.Errorf("qb: both first and limit given for %s", table)
+	}
+
+	var where Query
+	for _, name := range names {
+		switch name {
+		case "orderBy":
+			terms, ok := args[name].(string)
+			if !ok {
+				return SelectQuery{}, fmt.Errorf("qb: orderBy must be a string, got %T", args[name])
+			}
+			order, err := ParseSort(terms, allowed)
+			if err != nil {
+				return SelectQuery{}, err
+			}
+			q = q.Sort(order)
+			continue
+		case "first", "limit":
+			n, err := graphQLInt(args[name])
+			if err != nil {
+				return SelectQuery{}, fmt.Errorf("qb: %s: %w", name, err)
+			}
+			q = q.Limit(n)
+			continue
+		}
+		column, err := allowed.Column(name)
+		if err != nil {
+			return SelectQuery{}, err
+		}
+		var cond Query = Equal(column, args[name])
+		if where != nil {
+			cond = And(where, cond)
+		}
+		where = cond
+	}
+	if where != nil {
+		q = q.Where(where)
+	}
+	return q, nil
+}
+
+// graphQLInt returns the value of a positive integer argument, which GraphQL libraries
+// pass as any of the integer types or as a float64 when decoded from JSON.
+func graphQLInt(v interface{}) (int, error) {
+	var n int
+	switch v := v.(type) {
+	case int:
+		n = v
+	case int32:
+		n = int(v)
+	case int64:
+		n = int(v)
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("%v is not an integer", v)
+		}
+		n = int(v)
+	default:
+		return 0, fmt.Errorf("expected an integer, got %T", v)
+	}
+	// A limit of zero would remove the cap rather than return nothing.
+	if n <= 0 {
+		return 0, fmt.Errorf("%d is not positive", n)
+	}
+	return n, nil
+}
@@ -0,0 +1,87 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+var vehicleFields = qb.FieldMap{
+	"id":        "id",
+	"make":      "make",
+	"cost":      "cost",
+	"createdAt": "created_at",
+}
+
+func TestGraphQLSelect(t *testing.T) {
+	selectAll, err := qb.GraphQLSelect("vehicles", vehicleFields, []string{"id", "createdAt"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filtered, err := qb.GraphQLSelect("vehicles", vehicleFields, []string{"id"}, map[string]interface{}{
+		"make": "Honda",
+		"cost": 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	paged, err := qb.GraphQLSelect("vehicles", vehicleFields, []string{"id"}, map[string]interface{}{
+		"make":    "Honda",
+		"orderBy": "-createdAt,id",
+		"first":   float64(20),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:  "projection",
+			query: selectAll,
+			want: output{
+				query: `SELECT id, created_at FROM vehicles`,
+			},
+		},
+		testcase{
+			name:  "filter arguments",
+			query: filtered,
+			want: output{
+				query: `SELECT id FROM vehicles WHERE (cost = ? AND make = ?)`,
+				vals:  []interface{}{10, "Honda"},
+			},
+		},
+		testcase{
+			name:  "paging arguments",
+			query: paged,
+			want: output{
+				query: `SELECT id FROM vehicles WHERE make = ? ORDER BY created_at DESC, id ASC LIMIT ?`,
+				vals:  []interface{}{"Honda", 20},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+
+	if _, err := qb.GraphQLSelect("vehicles", vehicleFields, nil, nil); err == nil {
+		t.Error("expected an error for an empty selection")
+	}
+	if _, err := qb.GraphQLSelect("vehicles", vehicleFields, []string{"vin"}, nil); err == nil {
+		t.Error("expected an error selecting a field outside the allowlist")
+	}
+	if _, err := qb.GraphQLSelect("vehicles", vehicleFields, []string{"id"}, map[string]interface{}{"vin": "x"}); err == nil {
+		t.Error("expected an error filtering on a field outside the allowlist")
+	}
+	for _, args := range []map[string]interface{}{
+		{"orderBy": "vin"},
+		{"orderBy": 1},
+		{"first": -1},
+		{"first": 0},
+		{"limit": 2.5},
+		{"first": 1, "limit": 1},
+	} {
+		if _, err := qb.GraphQLSelect("vehicles", vehicleFields, []string{"id"}, args); err == nil {
+			t.Errorf("expected an error for arguments %v", args)
+		}
+	}
+}
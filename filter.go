@@ -0,0 +1,88 @@
+package qb
+
+// NewFilter returns a named predicate that can be attached to any query with a
+// WHERE clause, so that a business rule such as "active dealers in a region" is
+// defined once rather than repeated for every statement that needs it.
+func NewFilter(name string, cond Query) Filter {
+	return Filter{
+		Name: name,
+		Cond: cond,
+	}
+}
+
+// Filter represents a reusable predicate. The name is only used to identify the
+// filter when debugging and doesn't appear in the query.
+type Filter struct {
+	Name string
+	Cond Query
+}
+
+// Build returns the condition of the filter.
+func (f Filter) Build() string {
+	return f.Cond.Build()
+}
+
+func (f Filter) String() string {
+	return f.Build()
+}
+
+// Values returns the values of the condition.
+func (f Filter) Values() []interface{} {
+	return f.Cond.Values()
+}
+
+// Filter adds the filters to the WHERE clause of the query, combined with any
+// existing condition using AND.
+func (q SelectQuery) Filter(filters ...Filter) SelectQuery {
+	for _, f := range filters {
+		q.WhereClause, q.Vals = andWhere(q.WhereClause, q.Vals, f)
+	}
+	return q
+}
+
+// Filter adds the filters to the WHERE clause of the query, combined with any
+// existing condition using AND.
+func (q DeleteQuery) Filter(filters ...Filter) DeleteQuery {
+	for _, f := range filters {
+		q.WhereClause, q.Vals = andWhere(q.WhereClause, q.Vals, f)
+	}
+	return q
+}
+
+// Filter adds the filters to the WHERE clause of the query, combined with any
+// existing condition using AND.
+func (q UpdateQuery) Filter(filters ...Filter) UpdateQuery {
+	for _, f := range filters {
+		q.WhereClause, q.Vals = andWhere(q.WhereClause, q.Vals, f)
+	}
+	return q
+}
+
+// Filter adds the filters to the WHERE clause of the joined query, combined
+// with any existing condition using AND. The filters apply to the joined rows
+// whatever the type of join, and should use qualified column names since
+// every table is in scope.
+func (q JoinQuery) Filter(filters ...Filter) JoinQuery {
+	for _, f := range filters {
+		q.WhereClause, q.Vals = andWhere(q.WhereClause, q.Vals, f)
+	}
+	return q
+}
+
+// andWhere combines a WHERE clause and its values with another condition.
+func andWhere(where Query, vals []interface{}, cond Query) (Query, []interface{}) {
+	vals = append(vals[:len(vals):len(vals)], cond.Values()...)
+	if where == nil {
+		return cond, vals
+	}
+	return And(where, cond), vals
+}
+
+// orWhere is like andWhere, but combines the conditions with OR.
+func orWhere(where Query, vals []interface{}, cond Query) (Query, []interface{}) {
+	vals = append(vals[:len(vals):len(vals)], cond.Values()...)
+	if where == nil {
+		return cond, vals
+	}
+	return Or(where, cond), vals
+}
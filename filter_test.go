@@ -0,0 +1,61 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestFilter(t *testing.T) {
+	active := qb.NewFilter("active", qb.Equal("dealerships.active", true))
+	inRegion := qb.NewFilter("in region", qb.Equal("dealerships.region", "northeast"))
+
+	testcases := []testcase{
+		testcase{
+			name:  "select",
+			query: qb.Select("dealerships", "id").Where(qb.Greater("id", 10)).Filter(active, inRegion),
+			want: output{
+				query: `SELECT id FROM dealerships WHERE ((id > ? AND dealerships.active = ?) AND dealerships.region = ?)`,
+				vals:  []interface{}{10, true, "northeast"},
+			},
+		},
+		testcase{
+			name:  "delete",
+			query: qb.Delete("dealerships").Filter(inRegion),
+			want: output{
+				query: `DELETE FROM dealerships WHERE dealerships.region = ?`,
+				vals:  []interface{}{"northeast"},
+			},
+		},
+		testcase{
+			name: "join",
+			query: qb.Join(
+				qb.Select("dealerships", "name"),
+				qb.Select("employees", "id").Where(qb.Equal("role", "sales")),
+			).On("dealerships.id", "employees.dealership_id").Filter(active),
+			want: output{
+				query: `SELECT dealerships.name, employees.id FROM dealerships, employees WHERE dealerships.id = employees.dealership_id AND (role = ?) AND (dealerships.active = ?)`,
+				vals:  []interface{}{"sales", true},
+			},
+		},
+		testcase{
+			name: "right join",
+			query: qb.RightJoin(
+				qb.Select("dealerships", "name").Where(qb.Equal("state", "NY")),
+				qb.Select("employees", "id"),
+			).On("dealerships.id", "employees.dealership_id").Filter(active),
+			want: output{
+				query: `SELECT dealerships.name, employees.id FROM dealerships RIGHT JOIN employees ON dealerships.id = employees.dealership_id AND (state = ?) WHERE (dealerships.active = ?)`,
+				vals:  []interface{}{"NY", true},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+
+	ok, err := qb.Match(active, map[string]interface{}{"active": true})
+	if err != nil || !ok {
+		t.Errorf("expected filter to match, got %t, %v", ok, err)
+	}
+}
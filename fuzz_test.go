@@ -0,0 +1,130 @@
+package qb_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+// sentinel is a value that can't appear in any query string on its own, used
+// to check that query strings don't depend on the values bound to them.
+const sentinel = "\x00sentinel\x00"
+
+// valueQueries builds a variety of query trees around a single user-supplied
+// value. Every builder must produce a query string that doesn't depend on the
+// value and has one placeholder per value.
+var valueQueries = map[string]func(v interface{}) qb.Query{
+	"comparison": func(v interface{}) qb.Query {
+		return qb.Equal("make", v)
+	},
+	"boolean": func(v interface{}) qb.Query {
+		return qb.Or(qb.Greater("cost", v), qb.And(qb.Less("dol", v), qb.LessEqual("year", v)))
+	},
+	"in": func(v interface{}) qb.Query {
+		return qb.Select("vehicles", "id").Where(qb.In("make", v, v))
+	},
+	"select": func(v interface{}) qb.Query {
+		return qb.Select("vehicles", "id").Where(qb.GreaterEqual("cost", v)).Limit(10).Offset(5)
+	},
+	"select expression": func(v interface{}) qb.Query {
+		return qb.Select("vehicles", "id").
+			Expr(qb.As(qb.Select("photos", "COUNT(*)").Where(qb.Equal("kind", v)), "photo_count")).
+			Where(qb.Equal("make", v))
+	},
+	"sub query": func(v interface{}) qb.Query {
+		return qb.Select("photos", "url").Where(qb.Equal("vehicle_id", qb.Select("vehicles", "id").Where(qb.Equal("make", v))))
+	},
+	"delete": func(v interface{}) qb.Query {
+		return qb.Delete("vehicles").Where(qb.Equal("make", v)).Limit(10)
+	},
+	"update": func(v interface{}) qb.Query {
+		return qb.Update("vehicles").Set("make", v).Set("model", qb.Select("models", "name").Where(qb.Equal("id", v))).Where(qb.Equal("make", v))
+	},
+	"join": func(v interface{}) qb.Query {
+		return qb.Join(
+			qb.Select("employees", "id").Where(qb.Equal("role", v)),
+			qb.Select("dealerships", "name").Where(qb.Equal("state", v)),
+		).On("employees.dealership_id", "dealerships.id")
+	},
+	"left join": func(v interface{}) qb.Query {
+		return qb.LeftJoin(
+			qb.Select("employees", "id").Where(qb.Equal("role", v)),
+			qb.Select("dealerships", "name").Where(qb.Equal("state", v)),
+		).On("employees.dealership_id", "dealerships.id")
+	},
+	"chained join": func(v interface{}) qb.Query {
+		return qb.Select("employees", "id").Where(qb.Equal("role", v)).
+			Join("dealerships", "name").On("employees.dealership_id", "dealerships.id").
+			JoinWith("LEFT", qb.Select("sales", "total").Where(qb.Greater("total", v))).On("sales.employee_id", "employees.id")
+	},
+	"values table": func(v interface{}) qb.Query {
+		return qb.SelectFrom(qb.ValuesTable("v", []string{"a", "b"}, [][]interface{}{{v, v}, {v, v}}), "a")
+	},
+	"template": func(v interface{}) qb.Query {
+		return qb.Template("SELECT id FROM vehicles {{where}}").Where(qb.Equal("make", v))
+	},
+	"sequence": func(v interface{}) qb.Query {
+		return qb.Select("invoices").Where(qb.And(qb.Less("number", qb.NextVal("seq")), qb.Equal("id", v)))
+	},
+	"any": func(v interface{}) qb.Query {
+		return qb.Select("vehicles", "id").Where(qb.And(qb.Any("make", []interface{}{v, v}), qb.Equal("year", v)))
+	},
+	"having": func(v interface{}) qb.Query {
+		return qb.Select("vehicles", "make").Where(qb.Equal("year", v)).GroupBy("make").Having(qb.HavingAggregate("MAX", "cost", "<", v))
+	},
+	"window": func(v interface{}) qb.Query {
+		return qb.Select("vehicles", "id").
+			Expr(qb.As(qb.Over(qb.Raw("row_number()")).Sort(qb.OrderByClause{qb.OrderByExpr(qb.Equal("make", v), qb.Desc)}), "rank")).
+			Expr(qb.As(qb.StringAgg("make", "").Sort(qb.OrderByClause{qb.OrderByExpr(qb.Raw("cost - ?", v), qb.Asc)}), "makes")).
+			Where(qb.Equal("color", v))
+	},
+}
+
+func checkValueQuery(t *testing.T, name string, build func(v interface{}) qb.Query, v interface{}) {
+	t.Helper()
+
+	q := build(v)
+	got := q.Build()
+	if want := build(sentinel).Build(); got != want {
+		t.Errorf("%s: query string depends on the value %#v:\n\twanted:\n%s\n\tgot:\n%s", name, v, want, got)
+	}
+	if s, ok := v.(string); ok && len(s) > 8 && strings.Contains(got, s) {
+		t.Errorf("%s: value %q appears in query string %q", name, s, got)
+	}
+	if n, vals := strings.Count(got, "?"), q.Values(); n != len(vals) {
+		t.Errorf("%s: query string %q has %d placeholders but %d values", name, got, n, len(vals))
+	}
+}
+
+func TestValuesAreNeverRendered(t *testing.T) {
+	values := []interface{}{
+		"Honda",
+		"'; DROP TABLE vehicles; --",
+		"*/ DROP TABLE vehicles; /*",
+		"?",
+		"",
+		42,
+		3.14,
+		nil,
+		true,
+	}
+	for name, build := range valueQueries {
+		for _, v := range values {
+			checkValueQuery(t, name, build, v)
+		}
+	}
+}
+
+func FuzzBuild(f *testing.F) {
+	f.Add("Honda", int64(42))
+	f.Add("'; DROP TABLE vehicles; --", int64(-1))
+	f.Add("?", int64(0))
+
+	f.Fuzz(func(t *testing.T, s string, n int64) {
+		for name, build := range valueQueries {
+			checkValueQuery(t, name, build, s)
+			checkValueQuery(t, name, build, n)
+		}
+	})
+}
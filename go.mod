@@ -1,5 +1,7 @@
 module github.com/haleyrc/qb
 
+go 1.18
+
 require (
 	github.com/davecgh/go-spew v1.1.1
 	github.com/jmoiron/sqlx v1.2.0
@@ -0,0 +1,205 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Grant returns a query that resolves to the general form `GRANT privileges ON
+// objects TO roles`. With no privileges, ALL is granted.
+func Grant(privileges ...string) GrantQuery {
+	return GrantQuery{
+		Privileges: privileges,
+	}
+}
+
+// Revoke returns a query that resolves to the general form `REVOKE privileges
+// ON objects FROM roles`. With no privileges, ALL is revoked.
+func Revoke(privileges ...string) GrantQuery {
+	return GrantQuery{
+		Revoking:   true,
+		Privileges: privileges,
+	}
+}
+
+// GrantQuery represents a GRANT or REVOKE statement. DDL statements can't take
+// bound parameters, so every part of the statement is rendered inline and
+// should only ever come from trusted input.
+type GrantQuery struct {
+	Revoking    bool
+	Privileges  []string
+	ObjectType  string
+	Objects     []string
+	Roles       []string
+	GrantOption bool
+}
+
+// Build returns a query string of the general form `GRANT privileges ON type
+// objects TO roles [WITH GRANT OPTION]` or `REVOKE privileges ON type objects
+// FROM roles`.
+func (q GrantQuery) Build() string {
+	privileges := "ALL"
+	if len(q.Privileges) > 0 {
+		privileges = strings.Join(q.Privileges, ", ")
+	}
+	on := strings.Join(q.Objects, ", ")
+	if q.ObjectType != "" {
+		on = q.ObjectType + " " + on
+	}
+	roles := strings.Join(q.Roles, ", ")
+
+	if q.Revoking {
+		return fmt.Sprintf("REVOKE %s ON %s FROM %s", privileges, on, roles)
+	}
+	stmt := fmt.Sprintf("GRANT %s ON %s TO %s", privileges, on, roles)
+	if q.GrantOption {
+		stmt += " WITH GRANT OPTION"
+	}
+	return stmt
+}
+
+func (q GrantQuery) String() string {
+	return q.Build()
+}
+
+// Values always returns nil for GrantQuery.
+func (q GrantQuery) Values() []interface{} {
+	return nil
+}
+
+// ToSql is like SelectQuery.ToSql.
+func (q GrantQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(q)
+}
+
+// BuildFor is like SelectQuery.BuildFor.
+func (q GrantQuery) BuildFor(d Dialect) string {
+	return BuildFor(q, d)
+}
+
+// Kind returns KindDDL.
+func (q GrantQuery) Kind() Kind {
+	return KindDDL
+}
+
+// OnTable sets the tables the privileges apply to.
+func (q GrantQuery) OnTable(tables ...string) GrantQuery {
+	q.ObjectType = "TABLE"
+	q.Objects = tables
+	return q
+}
+
+// OnSchema sets the schemas the privileges apply to.
+func (q GrantQuery) OnSchema(schemas ...string) GrantQuery {
+	q.ObjectType = "SCHEMA"
+	q.Objects = schemas
+	return q
+}
+
+// OnAllTablesInSchema applies the privileges to every existing table in the
+// given schemas.
+func (q GrantQuery) OnAllTablesInSchema(schemas ...string) GrantQuery {
+	q.ObjectType = "ALL TABLES IN SCHEMA"
+	q.Objects = schemas
+	return q
+}
+
+// To sets the roles that receive the privileges.
+func (q GrantQuery) To(roles ...string) GrantQuery {
+	q.Roles = roles
+	return q
+}
+
+// From sets the roles that lose the privileges. It is equivalent to To, but
+// reads better with Revoke.
+func (q GrantQuery) From(roles ...string) GrantQuery {
+	return q.To(roles...)
+}
+
+// WithGrantOption allows the receiving roles to grant the privileges to others.
+func (q GrantQuery) WithGrantOption() GrantQuery {
+	q.GrantOption = true
+	return q
+}
+
+// CreateRole returns a query that resolves to the general form `CREATE ROLE
+// name [WITH options]`.
+func CreateRole(name string) RoleQuery {
+	return RoleQuery{
+		Keyword: "ROLE",
+		Name:    name,
+	}
+}
+
+// CreateUser returns a query that resolves to the general form `CREATE USER
+// name [WITH options]`. On Postgres this is the same as CreateRole with LOGIN.
+func CreateUser(name string) RoleQuery {
+	return RoleQuery{
+		Keyword: "USER",
+		Name:    name,
+	}
+}
+
+// RoleQuery represents a CREATE ROLE or CREATE USER statement using Postgres
+// syntax. Like GrantQuery, everything is rendered inline.
+type RoleQuery struct {
+	// Keyword is the type of object created, either ROLE or USER.
+	Keyword string
+	Name    string
+	Options []string
+}
+
+// Build returns a query string of the general form `CREATE ROLE name [WITH
+// options]`.
+func (q RoleQuery) Build() string {
+	stmt := fmt.Sprintf("CREATE %s %s", q.Keyword, q.Name)
+	if len(q.Options) > 0 {
+		stmt += " WITH " + strings.Join(q.Options, " ")
+	}
+	return stmt
+}
+
+func (q RoleQuery) String() string {
+	return q.Build()
+}
+
+// Values always returns nil for RoleQuery.
+func (q RoleQuery) Values() []interface{} {
+	return nil
+}
+
+// ToSql is like SelectQuery.ToSql.
+func (q RoleQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(q)
+}
+
+// BuildFor is like SelectQuery.BuildFor.
+func (q RoleQuery) BuildFor(d Dialect) string {
+	return BuildFor(q, d)
+}
+
+// Kind returns KindDDL.
+func (q RoleQuery) Kind() Kind {
+	return KindDDL
+}
+
+// Login allows the role to log in.
+func (q RoleQuery) Login() RoleQuery {
+	return q.with("LOGIN")
+}
+
+// Password sets the password for the role. The password is quoted as a string
+// literal.
+func (q RoleQuery) Password(password string) RoleQuery {
+	return q.with("PASSWORD " + quoteLiteral(password))
+}
+
+// InRole adds the new role as a member of the given roles.
+func (q RoleQuery) InRole(roles ...string) RoleQuery {
+	return q.with("IN ROLE " + strings.Join(roles, ", "))
+}
+
+func (q RoleQuery) with(option string) RoleQuery {
+	q.Options = append(q.Options[:len(q.Options):len(q.Options)], option)
+	return q
+}
@@ -0,0 +1,50 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestGrants(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name:  "grant on table",
+			query: qb.Grant("SELECT", "INSERT").OnTable("vehicles", "photos").To("reporting"),
+			want: output{
+				query: `GRANT SELECT, INSERT ON TABLE vehicles, photos TO reporting`,
+			},
+		},
+		testcase{
+			name:  "grant all with grant option",
+			query: qb.Grant().OnAllTablesInSchema("public").To("admin").WithGrantOption(),
+			want: output{
+				query: `GRANT ALL ON ALL TABLES IN SCHEMA public TO admin WITH GRANT OPTION`,
+			},
+		},
+		testcase{
+			name:  "revoke on schema",
+			query: qb.Revoke("USAGE").OnSchema("billing").From("reporting", "support"),
+			want: output{
+				query: `REVOKE USAGE ON SCHEMA billing FROM reporting, support`,
+			},
+		},
+		testcase{
+			name:  "create role",
+			query: qb.CreateRole("reporting"),
+			want: output{
+				query: `CREATE ROLE reporting`,
+			},
+		},
+		testcase{
+			name:  "create user with options",
+			query: qb.CreateUser("alice").Login().Password("it's secret").InRole("reporting"),
+			want: output{
+				query: `CREATE USER alice WITH LOGIN PASSWORD 'it''s secret' IN ROLE reporting`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
@@ -0,0 +1,26 @@
+package qb
+
+import "fmt"
+
+// HavingCountGreater returns a condition for use with SelectQuery.Having that
+// resolves to the form `COUNT(*) > ?`.
+func HavingCountGreater(n int) ComparisonClause {
+	return Greater("COUNT(*)", n)
+}
+
+// HavingCountEqual returns a condition for use with SelectQuery.Having that
+// resolves to the form `COUNT(*) = ?`.
+func HavingCountEqual(n int) ComparisonClause {
+	return Equal("COUNT(*)", n)
+}
+
+// HavingAggregate returns a condition for use with SelectQuery.Having that
+// compares an aggregate of a field with a value using the form `fn(field) op
+// ?`, for example `HavingAggregate("SUM", "cost", ">=", 1000)`.
+func HavingAggregate(fn, field, op string, value interface{}) ComparisonClause {
+	return ComparisonClause{
+		Op:    op,
+		Field: fmt.Sprintf("%s(%s)", fn, field),
+		Value: value,
+	}
+}
@@ -0,0 +1,38 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestHaving(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name: "count",
+			query: qb.Select("vehicles", "make").
+				Where(qb.Equal("year", 2020)).
+				GroupBy("make").
+				Having(qb.HavingCountGreater(5)).
+				Sort(qb.OrderByClause{{Field: "make"}}),
+			want: output{
+				query: `SELECT make FROM vehicles WHERE year = ? GROUP BY make HAVING COUNT(*) > ? ORDER BY make`,
+				vals:  []interface{}{2020, 5},
+			},
+		},
+		testcase{
+			name: "combined",
+			query: qb.Select("vehicles", "make", "model").
+				GroupBy("make", "model").
+				Having(qb.HavingCountEqual(1)).
+				Having(qb.HavingAggregate("SUM", "cost", ">=", 1000)),
+			want: output{
+				query: `SELECT make, model FROM vehicles GROUP BY make, model HAVING (COUNT(*) = ? AND SUM(cost) >= ?)`,
+				vals:  []interface{}{1, 1000},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
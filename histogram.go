@@ -0,0 +1,72 @@
+package qb
+
+import "fmt"
+
+// WidthBucket returns an expression that resolves to the form
+// `width_bucket(field, ?, ?, ?)`, which assigns a numeric value to one of n
+// equal-width buckets between min and max. Buckets are numbered from 1, with 0
+// for values below min and n+1 for values at or above max. Use
+// WithoutWidthBucket on databases without the function, such as MySQL.
+func WidthBucket(field string, min, max float64, n int) WidthBucketExpr {
+	return WidthBucketExpr{
+		Field:   field,
+		Min:     min,
+		Max:     max,
+		Buckets: n,
+	}
+}
+
+// WidthBucketExpr represents the bucket number of a numeric value in a
+// histogram.
+type WidthBucketExpr struct {
+	Field    string
+	Min      float64
+	Max      float64
+	Buckets  int
+	Emulated bool
+}
+
+// WithoutWidthBucket computes the bucket with a CASE expression that gives the
+// same result as width_bucket using only standard arithmetic.
+func (e WidthBucketExpr) WithoutWidthBucket() WidthBucketExpr {
+	e.Emulated = true
+	return e
+}
+
+// Build returns an expression of the form `width_bucket(field, ?, ?, ?)`, or
+// `CASE WHEN field < ? THEN 0 WHEN field >= ? THEN ? ELSE FLOOR((field - ?) * ?
+// / (? - ?)) + 1 END` if emulated.
+func (e WidthBucketExpr) Build() string {
+	if e.Emulated {
+		return fmt.Sprintf("CASE WHEN %[1]s < ? THEN 0 WHEN %[1]s >= ? THEN ? ELSE FLOOR((%[1]s - ?) * ? / (? - ?)) + 1 END", e.Field)
+	}
+	return fmt.Sprintf("width_bucket(%s, ?, ?, ?)", e.Field)
+}
+
+func (e WidthBucketExpr) String() string {
+	return e.Build()
+}
+
+// Values returns the bounds and number of buckets in the order they appear in
+// the expression.
+func (e WidthBucketExpr) Values() []interface{} {
+	if e.Emulated {
+		return []interface{}{e.Min, e.Max, e.Buckets + 1, e.Min, e.Buckets, e.Max, e.Min}
+	}
+	return []interface{}{e.Min, e.Max, e.Buckets}
+}
+
+func (e WidthBucketExpr) scalar() {}
+
+// Histogram returns a query counting the rows matched by base in each bucket,
+// of the form `SELECT bucket_expr AS bucket, COUNT(*) FROM table WHERE ... GROUP
+// BY bucket ORDER BY bucket`. Empty buckets are not returned. As with
+// FacetQueries, only the table and conditions of base are kept.
+func Histogram(base SelectQuery, bucket WidthBucketExpr) SelectQuery {
+	q := facetBase(base)
+	q.Fields = nil
+	q.Exprs = []Query{As(bucket, "bucket"), AggregateClause{Func: "COUNT", Field: "*"}}
+	q.Groups = []string{"bucket"}
+	q.Ordering = OrderByClause{{Field: "bucket"}}
+	return q
+}
@@ -0,0 +1,34 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestHistogram(t *testing.T) {
+	base := qb.Select("vehicles", "id").Where(qb.Equal("make", "Honda")).OrderBy("id", qb.Asc)
+	cost := qb.WidthBucket("cost", 0, 50000, 10)
+
+	testcases := []testcase{
+		testcase{
+			name:  "width bucket",
+			query: qb.Histogram(base, cost),
+			want: output{
+				query: `SELECT width_bucket(cost, ?, ?, ?) AS bucket, COUNT(*) FROM vehicles WHERE make = ? GROUP BY bucket ORDER BY bucket`,
+				vals:  []interface{}{0.0, 50000.0, 10, "Honda"},
+			},
+		},
+		testcase{
+			name:  "emulated",
+			query: cost.WithoutWidthBucket(),
+			want: output{
+				query: `CASE WHEN cost < ? THEN 0 WHEN cost >= ? THEN ? ELSE FLOOR((cost - ?) * ? / (? - ?)) + 1 END`,
+				vals:  []interface{}{0.0, 50000.0, 11, 0.0, 10, 50000.0, 0.0},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
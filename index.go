@@ -0,0 +1,88 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CreateIndex returns a query that resolves to the general form `CREATE INDEX
+// name ON table (columns)`.
+func CreateIndex(name, table string, columns ...string) CreateIndexQuery {
+	return CreateIndexQuery{
+		Name:    name,
+		Table:   table,
+		Columns: columns,
+	}
+}
+
+// CreateIndexQuery represents a query that resolves to the general form
+// `CREATE [UNIQUE] INDEX [CONCURRENTLY] [IF NOT EXISTS] name ON table
+// (columns)`.
+type CreateIndexQuery struct {
+	Name         string
+	Table        string
+	Columns      []string
+	IsUnique     bool
+	IsConcurrent bool
+	SkipExisting bool
+}
+
+// Build returns a query string of the general form `CREATE [UNIQUE] INDEX
+// [CONCURRENTLY] [IF NOT EXISTS] name ON table (columns)`.
+func (q CreateIndexQuery) Build() string {
+	stmt := "CREATE "
+	if q.IsUnique {
+		stmt += "UNIQUE "
+	}
+	stmt += "INDEX "
+	if q.IsConcurrent {
+		stmt += "CONCURRENTLY "
+	}
+	if q.SkipExisting {
+		stmt += "IF NOT EXISTS "
+	}
+	return stmt + fmt.Sprintf("%s ON %s (%s)", q.Name, q.Table, strings.Join(q.Columns, ", "))
+}
+
+func (q CreateIndexQuery) String() string {
+	return q.Build()
+}
+
+// Values always returns nil for CreateIndexQuery.
+func (q CreateIndexQuery) Values() []interface{} {
+	return nil
+}
+
+// ToSql is like SelectQuery.ToSql.
+func (q CreateIndexQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(q)
+}
+
+// BuildFor is like SelectQuery.BuildFor.
+func (q CreateIndexQuery) BuildFor(d Dialect) string {
+	return BuildFor(q, d)
+}
+
+// Kind returns KindDDL.
+func (q CreateIndexQuery) Kind() Kind {
+	return KindDDL
+}
+
+// Unique makes the index enforce uniqueness of the indexed columns.
+func (q CreateIndexQuery) Unique() CreateIndexQuery {
+	q.IsUnique = true
+	return q
+}
+
+// Concurrently builds the index without locking out writes to the table, which
+// is slower but safe to run against a live Postgres database.
+func (q CreateIndexQuery) Concurrently() CreateIndexQuery {
+	q.IsConcurrent = true
+	return q
+}
+
+// IfNotExists makes creating the index a no-op if it already exists.
+func (q CreateIndexQuery) IfNotExists() CreateIndexQuery {
+	q.SkipExisting = true
+	return q
+}
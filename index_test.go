@@ -0,0 +1,29 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestCreateIndex(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name:  "basic",
+			query: qb.CreateIndex("vehicles_make_idx", "vehicles", "make"),
+			want: output{
+				query: `CREATE INDEX vehicles_make_idx ON vehicles (make)`,
+			},
+		},
+		testcase{
+			name:  "options",
+			query: qb.CreateIndex("vehicles_vin_idx", "vehicles", "vin", "year").Unique().Concurrently().IfNotExists(),
+			want: output{
+				query: `CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS vehicles_vin_idx ON vehicles (vin, year)`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
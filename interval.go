@@ -0,0 +1,212 @@
+package qb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interval returns an expression that resolves to an interval literal of the
+// form `INTERVAL '1 day 2 hours'`. The literal is generated from the duration,
+// so it is rendered inline rather than bound. BuildFor writes it in the form of
+// the dialect; see IntervalExpr.Build.
+func Interval(d time.Duration) IntervalExpr {
+	return IntervalExpr{
+		Duration: d,
+	}
+}
+
+// IntervalFor is like Interval, but builds the interval for the dialect even
+// when the query it is used in is built with Build rather than BuildFor.
+func IntervalFor(dialect Dialect, d time.Duration) IntervalExpr {
+	return IntervalExpr{
+		Duration: d,
+		dialect:  dialect,
+	}
+}
+
+// IntervalExpr represents an interval literal.
+type IntervalExpr struct {
+	Duration time.Duration
+
+	dialect Dialect
+}
+
+// Build returns an expression of the form `INTERVAL '1 day 2 hours'`. MySQL
+// only accepts a single unit, so it gets the form `INTERVAL 26 HOUR` using the
+// largest unit that represents the duration exactly. SQLite has no interval
+// type and does date arithmetic with modifiers instead, so it gets a modifier
+// of the form `'+26 hours'`. SQL Server has no interval type either and an
+// interval can only be used with DateAdd and DateSub there, which build with
+// DATEADD.
+func (e IntervalExpr) Build() string {
+	switch dialectName(e.dialect) {
+	case "mysql":
+		n, unit := intervalUnit(e.Duration)
+		return fmt.Sprintf("INTERVAL %d %s", n, strings.ToUpper(unit))
+	case "sqlite":
+		return fmt.Sprintf("'%s'", sqliteModifier(e.Duration))
+	}
+	return fmt.Sprintf("INTERVAL '%s'", formatInterval(e.Duration))
+}
+
+func (e IntervalExpr) String() string {
+	return e.Build()
+}
+
+// Values always returns nil for IntervalExpr.
+func (e IntervalExpr) Values() []interface{} {
+	return nil
+}
+
+func (e IntervalExpr) scalar() {}
+
+func (e IntervalExpr) withDialect(d Dialect) interface{} {
+	e.dialect = d
+	return e
+}
+
+// intervalUnit returns d as a whole number of the largest unit that represents
+// it exactly, down to microseconds.
+func intervalUnit(d time.Duration) (int64, string) {
+	if d == 0 {
+		return 0, "second"
+	}
+	units := []struct {
+		name string
+		size time.Duration
+	}{
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+		{"second", time.Second},
+	}
+	for _, u := range units {
+		if d%u.size == 0 {
+			return int64(d / u.size), u.name
+		}
+	}
+	return int64(d / time.Microsecond), "microsecond"
+}
+
+// sqliteModifier formats d as a modifier for the SQLite date functions, e.g.
+// `+26 hours`. Durations that aren't a whole number of minutes are given in
+// fractional seconds, since SQLite has no smaller unit.
+func sqliteModifier(d time.Duration) string {
+	n, unit := intervalUnit(d)
+	count := strconv.FormatInt(n, 10)
+	if unit == "second" || unit == "microsecond" {
+		unit, count = "second", strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+	}
+	if d >= 0 {
+		count = "+" + count
+	}
+	return count + " " + unit + "s"
+}
+
+// formatInterval formats d in the verbose interval syntax accepted by
+// Postgres, using the largest units that represent it exactly.
+func formatInterval(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	units := []struct {
+		name string
+		size time.Duration
+	}{
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+	}
+	var parts []string
+	for _, u := range units {
+		if n := d / u.size; n > 0 {
+			parts = append(parts, plural(sign+strconv.FormatInt(int64(n), 10), u.name, n))
+			d -= n * u.size
+		}
+	}
+	if d > 0 || len(parts) == 0 {
+		secs := strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+		parts = append(parts, plural(sign+secs, "second", d/time.Second))
+	}
+	return strings.Join(parts, " ")
+}
+
+func plural(n, unit string, count time.Duration) string {
+	if count == 1 && !strings.Contains(n, ".") {
+		return n + " " + unit
+	}
+	return n + " " + unit + "s"
+}
+
+// DateAdd returns an expression that resolves to the form `field + INTERVAL
+// '...'`, e.g. for computing an expiry time. The field can be any expression,
+// such as `now()`.
+func DateAdd(field string, interval IntervalExpr) DateArithExpr {
+	return DateArithExpr{
+		Field:    field,
+		Op:       "+",
+		Interval: interval,
+	}
+}
+
+// DateSub returns an expression that resolves to the form `field - INTERVAL
+// '...'`, e.g. for finding rows older than a cutoff.
+func DateSub(field string, interval IntervalExpr) DateArithExpr {
+	return DateArithExpr{
+		Field:    field,
+		Op:       "-",
+		Interval: interval,
+	}
+}
+
+// DateArithExpr represents adding an interval to, or subtracting one from, a
+// date or timestamp.
+type DateArithExpr struct {
+	Field    string
+	Op       string
+	Interval IntervalExpr
+
+	dialect Dialect
+}
+
+// Build returns an expression of the form `field op INTERVAL '...'`, with the
+// interval written for the dialect. SQLite gets the form `datetime(field,
+// '+26 hours')` and SQL Server the form `DATEADD(hour, 26, field)`, since
+// neither of them can add intervals with an operator.
+func (e DateArithExpr) Build() string {
+	d := e.Interval.Duration
+	if e.Op == "-" {
+		d = -d
+	}
+	dialect := e.dialect
+	if dialect == nil {
+		dialect = e.Interval.dialect
+	}
+	switch dialectName(dialect) {
+	case "sqlite":
+		return fmt.Sprintf("datetime(%s, '%s')", e.Field, sqliteModifier(d))
+	case "sqlserver":
+		n, unit := intervalUnit(d)
+		return fmt.Sprintf("DATEADD(%s, %d, %s)", unit, n, e.Field)
+	}
+	return fmt.Sprintf("%s %s %s", e.Field, e.Op, e.Interval.Build())
+}
+
+func (e DateArithExpr) String() string {
+	return e.Build()
+}
+
+// Values always returns nil for DateArithExpr.
+func (e DateArithExpr) Values() []interface{} {
+	return nil
+}
+
+func (e DateArithExpr) scalar() {}
+
+func (e DateArithExpr) withDialect(d Dialect) interface{} {
+	e.dialect = d
+	return e
+}
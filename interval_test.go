@@ -0,0 +1,111 @@
+package qb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestInterval(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name:  "hours",
+			query: qb.Interval(3 * time.Hour),
+			want:  output{query: `INTERVAL '3 hours'`},
+		},
+		testcase{
+			name:  "mixed",
+			query: qb.Interval(26*time.Hour + time.Minute + 1500*time.Millisecond),
+			want:  output{query: `INTERVAL '1 day 2 hours 1 minute 1.5 seconds'`},
+		},
+		testcase{
+			name:  "negative",
+			query: qb.Interval(-90 * time.Minute),
+			want:  output{query: `INTERVAL '-1 hour -30 minutes'`},
+		},
+		testcase{
+			name:  "zero",
+			query: qb.Interval(0),
+			want:  output{query: `INTERVAL '0 seconds'`},
+		},
+		testcase{
+			name: "expiry",
+			query: qb.Select("sessions", "id").
+				Where(qb.Less("created_at", qb.DateSub("now()", qb.Interval(24*time.Hour)))),
+			want: output{query: `SELECT id FROM sessions WHERE created_at < now() - INTERVAL '1 day'`},
+		},
+		testcase{
+			name:  "update",
+			query: qb.Update("sessions").Set("expires_at", qb.DateAdd("expires_at", qb.Interval(time.Hour))).Where(qb.Equal("id", 1)),
+			want: output{
+				query: `UPDATE sessions SET expires_at = expires_at + INTERVAL '1 hour' WHERE id = ?`,
+				vals:  []interface{}{1},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
+
+func TestIntervalFor(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name:  "mysql",
+			query: qb.IntervalFor(qb.MySQL, 26*time.Hour),
+			want:  output{query: `INTERVAL 26 HOUR`},
+		},
+		testcase{
+			name:  "mysql days",
+			query: qb.IntervalFor(qb.MySQL, -48*time.Hour),
+			want:  output{query: `INTERVAL -2 DAY`},
+		},
+		testcase{
+			name:  "mysql fraction",
+			query: qb.IntervalFor(qb.MySQL, 1500*time.Millisecond),
+			want:  output{query: `INTERVAL 1500000 MICROSECOND`},
+		},
+		testcase{
+			name:  "sqlite",
+			query: qb.IntervalFor(qb.SQLite, 1500*time.Millisecond),
+			want:  output{query: `'+1.5 seconds'`},
+		},
+		testcase{
+			name:  "mysql expiry",
+			query: qb.DateSub("now()", qb.IntervalFor(qb.MySQL, 90*time.Minute)),
+			want:  output{query: `now() - INTERVAL 90 MINUTE`},
+		},
+		testcase{
+			name:  "sqlite expiry",
+			query: qb.DateSub("created_at", qb.IntervalFor(qb.SQLite, 24*time.Hour)),
+			want:  output{query: `datetime(created_at, '-1 days')`},
+		},
+		testcase{
+			name:  "sqlserver expiry",
+			query: qb.DateAdd("created_at", qb.IntervalFor(qb.SQLServer, 3*time.Hour)),
+			want:  output{query: `DATEADD(hour, 3, created_at)`},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
+
+func TestIntervalBuildFor(t *testing.T) {
+	q := qb.Select("sessions", "id").
+		Where(qb.Less("created_at", qb.DateSub("now()", qb.Interval(26*time.Hour))))
+
+	testcases := []struct {
+		dialect qb.Dialect
+		want    string
+	}{
+		{qb.Postgres, `SELECT id FROM sessions WHERE created_at < now() - INTERVAL '1 day 2 hours'`},
+		{qb.MySQL, `SELECT id FROM sessions WHERE created_at < now() - INTERVAL 26 HOUR`},
+		{qb.SQLite, `SELECT id FROM sessions WHERE created_at < datetime(now(), '-26 hours')`},
+		{qb.SQLServer, `SELECT id FROM sessions WHERE created_at < DATEADD(hour, -26, now())`},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.dialect.Name(), testFor(tc.dialect, testcase{query: q, want: output{query: tc.want}}))
+	}
+}
@@ -0,0 +1,80 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestJoinTypes(t *testing.T) {
+	employees := qb.Select("employees", "id").Where(qb.Equal("role", "admin"))
+	dealerships := qb.Select("dealerships", "name").Where(qb.Equal("state", "NY"))
+
+	testcases := []testcase{
+		testcase{
+			name:  "inner",
+			query: qb.InnerJoin(employees, dealerships).On("employees.dealership_id", "dealerships.id"),
+			want: output{
+				query: `SELECT employees.id, dealerships.name FROM employees INNER JOIN dealerships ON employees.dealership_id = dealerships.id WHERE (role = ?) AND (state = ?)`,
+				vals:  []interface{}{"admin", "NY"},
+			},
+		},
+		testcase{
+			name:  "left",
+			query: qb.LeftJoin(employees, dealerships).On("employees.dealership_id", "dealerships.id"),
+			want: output{
+				query: `SELECT employees.id, dealerships.name FROM employees LEFT JOIN dealerships ON employees.dealership_id = dealerships.id AND (state = ?) WHERE (role = ?)`,
+				vals:  []interface{}{"NY", "admin"},
+			},
+		},
+		testcase{
+			name:  "right",
+			query: qb.RightJoin(employees, qb.Select("dealerships", "name")).On("employees.dealership_id", "dealerships.id"),
+			want: output{
+				query: `SELECT employees.id, dealerships.name FROM employees RIGHT JOIN dealerships ON employees.dealership_id = dealerships.id AND (role = ?)`,
+				vals:  []interface{}{"admin"},
+			},
+		},
+		testcase{
+			name:  "full",
+			query: qb.FullJoin(qb.Select("employees", "id"), qb.Select("dealerships", "name")).On("employees.dealership_id", "dealerships.id"),
+			want: output{
+				query: `SELECT employees.id, dealerships.name FROM employees FULL JOIN dealerships ON employees.dealership_id = dealerships.id`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
+
+func TestJoinChain(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name: "three tables",
+			query: qb.Select("employees", "id").Where(qb.Equal("role", "admin")).
+				Join("dealerships", "name").On("employees.dealership_id", "dealerships.id").
+				Join("regions", "name").On("dealerships.region_id", "regions.id"),
+			want: output{
+				query: `SELECT employees.id, dealerships.name, regions.name FROM employees INNER JOIN dealerships ON employees.dealership_id = dealerships.id INNER JOIN regions ON dealerships.region_id = regions.id WHERE (role = ?)`,
+				vals:  []interface{}{"admin"},
+			},
+		},
+		testcase{
+			name: "left join with conditions",
+			query: qb.Join(
+				qb.Select("employees", "id").Where(qb.Equal("role", "admin")),
+				qb.Select("dealerships", "name").Where(qb.Equal("state", "NY")),
+			).On("employees.dealership_id", "dealerships.id").
+				JoinWith("LEFT", qb.Select("sales", "total").Where(qb.Greater("total", 1000))).On("sales.employee_id", "employees.id").
+				JoinWith("INNER", qb.Select("regions", "name").Where(qb.Equal("regions.name", "East"))).On("dealerships.region_id", "regions.id"),
+			want: output{
+				query: `SELECT employees.id, dealerships.name, sales.total, regions.name FROM employees INNER JOIN dealerships ON employees.dealership_id = dealerships.id LEFT JOIN sales ON sales.employee_id = employees.id AND (total > ?) INNER JOIN regions ON dealerships.region_id = regions.id WHERE (role = ?) AND (state = ?) AND (regions.name = ?)`,
+				vals:  []interface{}{1000, "admin", "NY", "East"},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
@@ -0,0 +1,752 @@
+package qb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// queryTypes maps the type tags used in the JSON encoding of queries to the
+// concrete types they decode to. Every Query implementation in this package
+// must be registered here.
+var queryTypes = map[string]reflect.Type{}
+
+func registerQueryType(name string, q Query) {
+	queryTypes[name] = reflect.TypeOf(q)
+}
+
+// rawQueryTypes are the registered types that carry SQL text which is used
+// verbatim. They are only decoded by UnmarshalTrustedQuery, so that a stored
+// document such as a saved search can't be turned into an arbitrary statement.
+var rawQueryTypes = map[string]bool{
+	"GrantQuery":    true,
+	"RawQuery":      true,
+	"RoleQuery":     true,
+	"TemplateQuery": true,
+}
+
+func init() {
+	registerQueryType("AggregateClause", AggregateClause{})
+	registerQueryType("AliasClause", AliasClause{})
+	registerQueryType("AnnotatedQuery", AnnotatedQuery{})
+	registerQueryType("AnyClause", AnyClause{})
+	registerQueryType("AuditQuery", AuditQuery{})
+	registerQueryType("BetweenClause", BetweenClause{})
+	registerQueryType("BoolClause", BoolClause(false))
+	registerQueryType("BooleanQuery", BooleanQuery{})
+	registerQueryType("CaptureQuery", CaptureQuery{})
+	registerQueryType("Column", Column(""))
+	registerQueryType("ComparisonClause", ComparisonClause{})
+	registerQueryType("CopyQuery", CopyQuery{})
+	registerQueryType("CreateIndexQuery", CreateIndexQuery{})
+	registerQueryType("CreateSequenceQuery", CreateSequenceQuery{})
+	registerQueryType("CreateTableAsQuery", CreateTableAsQuery{})
+	registerQueryType("DateArithExpr", DateArithExpr{})
+	registerQueryType("DateTruncExpr", DateTruncExpr{})
+	registerQueryType("DeleteQuery", DeleteQuery{})
+	registerQueryType("ExplainQuery", ExplainQuery{})
+	registerQueryType("Filter", Filter{})
+	registerQueryType("GrantQuery", GrantQuery{})
+	registerQueryType("HashExpr", HashExpr{})
+	registerQueryType("InClause", InClause{})
+	registerQueryType("IntervalExpr", IntervalExpr{})
+	registerQueryType("JSONObjectClause", JSONObjectClause{})
+	registerQueryType("JSONPathClause", JSONPathClause{})
+	registerQueryType("JoinQuery", JoinQuery{})
+	registerQueryType("MaskExpr", MaskExpr{})
+	registerQueryType("MedianExpr", MedianExpr{})
+	registerQueryType("NotClause", NotClause{})
+	registerQueryType("NullClause", NullClause{})
+	registerQueryType("On", On{})
+	registerQueryType("Order", Order{})
+	registerQueryType("OrderByClause", OrderByClause{})
+	registerQueryType("PercentileClause", PercentileClause{})
+	registerQueryType("RandomExpr", RandomExpr{})
+	registerQueryType("RawQuery", RawQuery{})
+	registerQueryType("RoleQuery", RoleQuery{})
+	registerQueryType("RowToJSONExpr", RowToJSONExpr{})
+	registerQueryType("SelectQuery", SelectQuery{})
+	registerQueryType("SequenceExpr", SequenceExpr{})
+	registerQueryType("TableFuncClause", TableFuncClause{})
+	registerQueryType("TemplateQuery", TemplateQuery{})
+	registerQueryType("TimeBucketExpr", TimeBucketExpr{})
+	registerQueryType("TimeZoneExpr", TimeZoneExpr{})
+	registerQueryType("UpdateQuery", UpdateQuery{})
+	registerQueryType("ValuesTableClause", ValuesTableClause{})
+	registerQueryType("WidthBucketExpr", WidthBucketExpr{})
+	registerQueryType("WindowClause", WindowClause{})
+	registerQueryType("XMLClause", XMLClause{})
+}
+
+// UnmarshalQuery decodes a query tree that was encoded with json.Marshal. Every
+// Query implementation in this package encodes itself as a JSON object with a
+// "type" field identifying its concrete type, which UnmarshalQuery uses to
+// restore the original types throughout the tree.
+//
+// Queries that carry SQL text verbatim, such as RawQuery, TemplateQuery,
+// GrantQuery and RoleQuery, are rejected wherever they appear in the tree,
+// since decoding them would run whatever SQL the document contains. Use
+// UnmarshalTrustedQuery for documents that only come from trusted sources.
+//
+// Numeric values decode as int64 if they are whole numbers and as float64
+// otherwise.
+func UnmarshalQuery(data []byte) (Query, error) {
+	return decoder{}.query(data)
+}
+
+// UnmarshalTrustedQuery is like UnmarshalQuery, but also decodes the queries
+// that carry SQL text verbatim. It must only be used for documents that were
+// written by the application itself.
+func UnmarshalTrustedQuery(data []byte) (Query, error) {
+	return decoder{trusted: true}.query(data)
+}
+
+// decoder decodes query trees. Only a trusted decoder accepts the types in
+// rawQueryTypes. The UnmarshalJSON methods of the query types use an untrusted
+// decoder, so decoding with json.Unmarshal is no more permissive than
+// UnmarshalQuery.
+type decoder struct {
+	trusted bool
+}
+
+func (d decoder) query(data []byte) (Query, error) {
+	var envelope struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("qb: decode query: %w", err)
+	}
+	t, ok := queryTypes[envelope.Type]
+	if !ok {
+		return nil, fmt.Errorf("qb: decode query: unknown type %q", envelope.Type)
+	}
+	ptr := reflect.New(t)
+	if t.Kind() == reflect.Struct {
+		if err := d.fields(envelope.Type, data, ptr.Interface()); err != nil {
+			return nil, err
+		}
+	} else if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+		return nil, err
+	}
+	return ptr.Elem().Interface().(Query), nil
+}
+
+// marshalQuery encodes a struct query as a JSON object with a "type" field
+// followed by its exported fields in declaration order.
+func marshalQuery(name string, q interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `{"type":%q`, name)
+
+	v := reflect.ValueOf(q)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		b, err := json.Marshal(v.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, `,%q:`, f.Name)
+		buf.Write(b)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// unmarshalQuery decodes a JSON object produced by marshalQuery into the struct
+// pointed to by q, restoring the concrete types of any nested queries.
+func unmarshalQuery(name string, data []byte, q interface{}) error {
+	return decoder{}.fields(name, data, q)
+}
+
+func (d decoder) fields(name string, data []byte, q interface{}) error {
+	if rawQueryTypes[name] && !d.trusted {
+		return fmt.Errorf("qb: decode %s: only decoded by UnmarshalTrustedQuery", name)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("qb: decode %s: %w", name, err)
+	}
+	if err := checkType(name, fields); err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(q).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		raw, ok := fields[f.Name]
+		if f.PkgPath != "" || !ok {
+			continue
+		}
+		if err := d.field(raw, v.Field(i)); err != nil {
+			return fmt.Errorf("qb: decode %s.%s: %w", name, f.Name, err)
+		}
+	}
+	return nil
+}
+
+func checkType(name string, fields map[string]json.RawMessage) error {
+	var tag string
+	if err := json.Unmarshal(fields["type"], &tag); err != nil || tag != name {
+		return fmt.Errorf("qb: decode %s: unexpected type %s", name, fields["type"])
+	}
+	return nil
+}
+
+var (
+	queryType      = reflect.TypeOf((*Query)(nil)).Elem()
+	valueType      = reflect.TypeOf((*interface{})(nil)).Elem()
+	joinClauseType = reflect.TypeOf(JoinClause{})
+)
+
+// tagged reports whether t is a struct encoded by marshalQuery under its own
+// name, whose fields may hold further queries.
+func tagged(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && (queryTypes[t.Name()] == t || t == joinClauseType)
+}
+
+func decodeField(raw json.RawMessage, field reflect.Value) error {
+	return decoder{}.field(raw, field)
+}
+
+func (d decoder) field(raw json.RawMessage, field reflect.Value) error {
+	if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return nil
+	}
+
+	switch t := field.Type(); {
+	case t.Kind() != reflect.Struct && t.Name() != "" && queryTypes[t.Name()] == t:
+		// Queries that aren't structs, such as OrderByClause, decode
+		// themselves and can't hold any other queries.
+		return json.Unmarshal(raw, field.Addr().Interface())
+	case t == queryType:
+		q, err := d.query(raw)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(q))
+	case t == valueType:
+		v, err := d.value(raw)
+		if err != nil {
+			return err
+		}
+		if v != nil {
+			field.Set(reflect.ValueOf(v))
+		}
+	case tagged(t):
+		return d.fields(t.Name(), raw, field.Addr().Interface())
+	case t.Kind() == reflect.Slice && (t.Elem() == queryType || t.Elem() == valueType || t.Elem().Kind() == reflect.Slice || tagged(t.Elem())):
+		var elems []json.RawMessage
+		if err := json.Unmarshal(raw, &elems); err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(t, len(elems), len(elems))
+		for i, elem := range elems {
+			if err := d.field(elem, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		field.Set(s)
+	case t.Kind() == reflect.Map && t.Elem() == queryType:
+		var elems map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &elems); err != nil {
+			return err
+		}
+		m := reflect.MakeMapWithSize(t, len(elems))
+		for k, elem := range elems {
+			q, err := d.query(elem)
+			if err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(k), reflect.ValueOf(q))
+		}
+		field.Set(m)
+	default:
+		return json.Unmarshal(raw, field.Addr().Interface())
+	}
+	return nil
+}
+
+// value decodes a comparison value. Objects carrying a known query type
+// tag are decoded as queries and those tagged as a Value are decoded as one;
+// everything else is decoded as plain JSON.
+func (d decoder) value(raw json.RawMessage) (interface{}, error) {
+	var probe struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(raw, &probe) == nil {
+		if probe.Type == "Value" {
+			var v Value
+			err := d.valueOf(raw, &v)
+			return v, err
+		}
+		if _, ok := queryTypes[probe.Type]; ok {
+			return d.query(raw)
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return convertNumbers(v), nil
+}
+
+func convertNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case []interface{}:
+		for i := range v {
+			v[i] = convertNumbers(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = convertNumbers(v[k])
+		}
+	}
+	return v
+}
+
+// marshalScalar encodes a query whose underlying type isn't a struct as a JSON
+// object with a "type" field and a single named field.
+func marshalScalar(name, field string, v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf(`{"type":%q,%q:%s}`, name, field, b)), nil
+}
+
+// unmarshalScalar decodes a JSON object produced by marshalScalar.
+func unmarshalScalar(name, field string, data []byte, v interface{}) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("qb: decode %s: %w", name, err)
+	}
+	if err := checkType(name, fields); err != nil {
+		return err
+	}
+	if raw, ok := fields[field]; ok {
+		return decodeField(raw, reflect.ValueOf(v).Elem())
+	}
+	return nil
+}
+
+func (c AggregateClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("AggregateClause", c)
+}
+
+func (c *AggregateClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("AggregateClause", b, c)
+}
+
+func (c AliasClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("AliasClause", c)
+}
+
+func (c *AliasClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("AliasClause", b, c)
+}
+
+func (q AnnotatedQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("AnnotatedQuery", q)
+}
+
+func (q *AnnotatedQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("AnnotatedQuery", b, q)
+}
+
+func (c AnyClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("AnyClause", c)
+}
+
+func (c *AnyClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("AnyClause", b, c)
+}
+
+func (q AuditQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("AuditQuery", q)
+}
+
+func (q *AuditQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("AuditQuery", b, q)
+}
+
+func (c BetweenClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("BetweenClause", c)
+}
+
+func (c *BetweenClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("BetweenClause", b, c)
+}
+
+func (c BoolClause) MarshalJSON() ([]byte, error) {
+	return marshalScalar("BoolClause", "Value", bool(c))
+}
+
+func (c *BoolClause) UnmarshalJSON(b []byte) error {
+	return unmarshalScalar("BoolClause", "Value", b, (*bool)(c))
+}
+
+func (q BooleanQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("BooleanQuery", q)
+}
+
+func (q *BooleanQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("BooleanQuery", b, q)
+}
+
+func (q CaptureQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("CaptureQuery", q)
+}
+
+func (q *CaptureQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("CaptureQuery", b, q)
+}
+
+func (c Column) MarshalJSON() ([]byte, error) {
+	return marshalScalar("Column", "Name", string(c))
+}
+
+func (c *Column) UnmarshalJSON(b []byte) error {
+	return unmarshalScalar("Column", "Name", b, (*string)(c))
+}
+
+func (c ComparisonClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("ComparisonClause", c)
+}
+
+func (c *ComparisonClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("ComparisonClause", b, c)
+}
+
+func (q CopyQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("CopyQuery", q)
+}
+
+func (q *CopyQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("CopyQuery", b, q)
+}
+
+func (q CreateIndexQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("CreateIndexQuery", q)
+}
+
+func (q *CreateIndexQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("CreateIndexQuery", b, q)
+}
+
+func (q CreateSequenceQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("CreateSequenceQuery", q)
+}
+
+func (q *CreateSequenceQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("CreateSequenceQuery", b, q)
+}
+
+func (q CreateTableAsQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("CreateTableAsQuery", q)
+}
+
+func (q *CreateTableAsQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("CreateTableAsQuery", b, q)
+}
+
+func (e DateArithExpr) MarshalJSON() ([]byte, error) {
+	return marshalQuery("DateArithExpr", e)
+}
+
+func (e *DateArithExpr) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("DateArithExpr", b, e)
+}
+
+func (e DateTruncExpr) MarshalJSON() ([]byte, error) {
+	return marshalQuery("DateTruncExpr", e)
+}
+
+func (e *DateTruncExpr) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("DateTruncExpr", b, e)
+}
+
+func (q DeleteQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("DeleteQuery", q)
+}
+
+func (q *DeleteQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("DeleteQuery", b, q)
+}
+
+func (q ExplainQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("ExplainQuery", q)
+}
+
+func (q *ExplainQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("ExplainQuery", b, q)
+}
+
+func (f Filter) MarshalJSON() ([]byte, error) {
+	return marshalQuery("Filter", f)
+}
+
+func (f *Filter) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("Filter", b, f)
+}
+
+func (q GrantQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("GrantQuery", q)
+}
+
+func (q *GrantQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("GrantQuery", b, q)
+}
+
+func (e HashExpr) MarshalJSON() ([]byte, error) {
+	return marshalQuery("HashExpr", e)
+}
+
+func (e *HashExpr) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("HashExpr", b, e)
+}
+
+func (c InClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("InClause", c)
+}
+
+func (c *InClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("InClause", b, c)
+}
+
+func (e IntervalExpr) MarshalJSON() ([]byte, error) {
+	return marshalQuery("IntervalExpr", e)
+}
+
+func (e *IntervalExpr) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("IntervalExpr", b, e)
+}
+
+func (c JSONObjectClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("JSONObjectClause", c)
+}
+
+func (c *JSONObjectClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("JSONObjectClause", b, c)
+}
+
+func (c JSONPathClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("JSONPathClause", c)
+}
+
+func (c *JSONPathClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("JSONPathClause", b, c)
+}
+
+func (q JoinQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("JoinQuery", q)
+}
+
+func (q *JoinQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("JoinQuery", b, q)
+}
+
+func (j JoinClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("JoinClause", j)
+}
+
+func (j *JoinClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("JoinClause", b, j)
+}
+
+func (e MaskExpr) MarshalJSON() ([]byte, error) {
+	return marshalQuery("MaskExpr", e)
+}
+
+func (e *MaskExpr) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("MaskExpr", b, e)
+}
+
+func (e MedianExpr) MarshalJSON() ([]byte, error) {
+	return marshalQuery("MedianExpr", e)
+}
+
+func (e *MedianExpr) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("MedianExpr", b, e)
+}
+
+func (c NotClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("NotClause", c)
+}
+
+func (c *NotClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("NotClause", b, c)
+}
+
+func (c NullClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("NullClause", c)
+}
+
+func (c *NullClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("NullClause", b, c)
+}
+
+func (o On) MarshalJSON() ([]byte, error) {
+	return marshalQuery("On", o)
+}
+
+func (o *On) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("On", b, o)
+}
+
+func (o Order) MarshalJSON() ([]byte, error) {
+	return marshalQuery("Order", o)
+}
+
+func (o *Order) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("Order", b, o)
+}
+
+func (c OrderByClause) MarshalJSON() ([]byte, error) {
+	return marshalScalar("OrderByClause", "Terms", []Order(c))
+}
+
+func (c *OrderByClause) UnmarshalJSON(b []byte) error {
+	return unmarshalScalar("OrderByClause", "Terms", b, (*[]Order)(c))
+}
+
+func (c PercentileClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("PercentileClause", c)
+}
+
+func (c *PercentileClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("PercentileClause", b, c)
+}
+
+func (e RandomExpr) MarshalJSON() ([]byte, error) {
+	return marshalQuery("RandomExpr", e)
+}
+
+func (e *RandomExpr) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("RandomExpr", b, e)
+}
+
+func (q RawQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("RawQuery", q)
+}
+
+func (q *RawQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("RawQuery", b, q)
+}
+
+func (q RoleQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("RoleQuery", q)
+}
+
+func (q *RoleQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("RoleQuery", b, q)
+}
+
+func (e RowToJSONExpr) MarshalJSON() ([]byte, error) {
+	return marshalQuery("RowToJSONExpr", e)
+}
+
+func (e *RowToJSONExpr) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("RowToJSONExpr", b, e)
+}
+
+func (q SelectQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("SelectQuery", q)
+}
+
+func (q *SelectQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("SelectQuery", b, q)
+}
+
+func (e SequenceExpr) MarshalJSON() ([]byte, error) {
+	return marshalQuery("SequenceExpr", e)
+}
+
+func (e *SequenceExpr) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("SequenceExpr", b, e)
+}
+
+func (c TableFuncClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("TableFuncClause", c)
+}
+
+func (c *TableFuncClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("TableFuncClause", b, c)
+}
+
+func (t TemplateQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("TemplateQuery", t)
+}
+
+func (t *TemplateQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("TemplateQuery", b, t)
+}
+
+func (e TimeBucketExpr) MarshalJSON() ([]byte, error) {
+	return marshalQuery("TimeBucketExpr", e)
+}
+
+func (e *TimeBucketExpr) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("TimeBucketExpr", b, e)
+}
+
+func (e TimeZoneExpr) MarshalJSON() ([]byte, error) {
+	return marshalQuery("TimeZoneExpr", e)
+}
+
+func (e *TimeZoneExpr) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("TimeZoneExpr", b, e)
+}
+
+func (q UpdateQuery) MarshalJSON() ([]byte, error) {
+	return marshalQuery("UpdateQuery", q)
+}
+
+func (q *UpdateQuery) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("UpdateQuery", b, q)
+}
+
+func (c ValuesTableClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("ValuesTableClause", c)
+}
+
+func (c *ValuesTableClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("ValuesTableClause", b, c)
+}
+
+func (e WidthBucketExpr) MarshalJSON() ([]byte, error) {
+	return marshalQuery("WidthBucketExpr", e)
+}
+
+func (e *WidthBucketExpr) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("WidthBucketExpr", b, e)
+}
+
+func (c WindowClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("WindowClause", c)
+}
+
+func (c *WindowClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("WindowClause", b, c)
+}
+
+func (c XMLClause) MarshalJSON() ([]byte, error) {
+	return marshalQuery("XMLClause", c)
+}
+
+func (c *XMLClause) UnmarshalJSON(b []byte) error {
+	return unmarshalQuery("XMLClause", b, c)
+}
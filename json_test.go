@@ -0,0 +1,143 @@
+package qb_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	redacted, err := qb.NewRedactionPolicy().Mask("ssn").Hash("email").Apply(qb.Select("users", "ssn", "email"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries := map[string]qb.Query{
+		"select": qb.Select("photos", "url").
+			Expr(qb.As(qb.Select("likes", "COUNT(*)").Where(qb.Equal("likes.photo_id", qb.Col("photos.id"))), "likes")).
+			Where(qb.And(
+				qb.Equal("public", true),
+				qb.Or(
+					qb.Equal("vehicle_id", qb.Select("vehicles", "id").Where(qb.Equal("make", "Honda"))),
+					qb.In("vehicle_id", 1, 2),
+				),
+			)).
+			Sort(qb.OrderByClause{{Field: "created_at", Dir: qb.Desc}}).
+			Hint("SeqScan(photos)"),
+		"delete": qb.Delete("events").Where(qb.Less("cost", 10.5)).Limit(100),
+		"update": qb.Update("vehicles").Set("cost", 100).Set("owner_id", qb.Select("people", "id").Where(qb.Equal("name", "Ann"))).Where(qb.Equal("id", 5)),
+		"join": qb.Join(
+			qb.Select("employees", "id").Where(qb.Equal("role", "admin")),
+			qb.SelectFrom(qb.ValuesTable("v", []string{"id"}, [][]interface{}{{1}, {2}}), "id"),
+		).On("employees.id", "v.id").Filter(qb.NewFilter("one", qb.Equal("v.id", 1))),
+		"template": qb.Template("SELECT * FROM t {{where}} {{order}}").Where(qb.Equal("a", "b")).Sort(qb.OrderByClause{{Field: "a"}}),
+		"redacted": redacted,
+		"null":     qb.Eq(map[string]interface{}{"sold_at": nil, "make": "Honda"}),
+		"hash":     qb.HashRows("vehicles", []string{"id"}, "make", "cost"),
+		"rowjson":  qb.Select("vehicles").Expr(qb.RowToJSON("vehicles")),
+		"explain":  qb.Explain(qb.Annotate(qb.Raw("SELECT ?", "x"), "req")),
+		"bool":     qb.Or(qb.Bool(false), qb.Equal("a", 1)),
+		"create":   qb.CreateTempTableAs("t", qb.Select("vehicles").Where(qb.Equal("id", qb.NextVal("seq")))),
+	}
+
+	for name, q := range queries {
+		t.Run(name, func(t *testing.T) {
+			b, err := json.Marshal(q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := qb.UnmarshalTrustedQuery(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reflect.TypeOf(got) != reflect.TypeOf(q) {
+				t.Errorf("wanted type %T, got %T", q, got)
+			}
+			if got.Build() != q.Build() {
+				t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", q.Build(), got.Build())
+			}
+			if !reflect.DeepEqual(normalizeValues(got.Values()), normalizeValues(q.Values())) {
+				t.Errorf("\n\twanted:\n%#v\n\tgot:\n%#v", q.Values(), got.Values())
+			}
+		})
+	}
+}
+
+func TestUnmarshalQueryRejectsRawSQL(t *testing.T) {
+	queries := map[string]qb.Query{
+		"raw":      qb.Raw("DELETE FROM vehicles"),
+		"nested":   qb.Select("vehicles").Where(qb.Equal("id", qb.Raw("SELECT 1; DROP TABLE vehicles"))),
+		"template": qb.Template("SELECT * FROM t {{where}}"),
+		"grant":    qb.Grant("ALL").OnTable("vehicles").To("public"),
+		"role":     qb.CreateRole("reporting"),
+	}
+	for name, q := range queries {
+		t.Run(name, func(t *testing.T) {
+			b, err := json.Marshal(q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := qb.UnmarshalQuery(b); err == nil {
+				t.Error("expected an error from UnmarshalQuery")
+			}
+			if _, err := qb.UnmarshalVersioned(b); err == nil {
+				t.Error("expected an error from UnmarshalVersioned")
+			}
+			if _, err := qb.UnmarshalTrustedQuery(b); err != nil {
+				t.Errorf("unexpected error from UnmarshalTrustedQuery: %v", err)
+			}
+		})
+	}
+
+	b, err := json.Marshal(queries["nested"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sq qb.SelectQuery
+	if err := json.Unmarshal(b, &sq); err == nil {
+		t.Error("expected an error decoding raw SQL with json.Unmarshal")
+	}
+}
+
+func TestJSONTypeTags(t *testing.T) {
+	b, err := json.Marshal(qb.And(qb.Equal("a", 1), qb.In("b")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"BooleanQuery","Op":"AND","Comparison1":{"type":"ComparisonClause","Op":"=","Field":"a","Value":1},"Comparison2":{"type":"InClause","Field":"b","Vals":null}}`
+	if string(b) != want {
+		t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", want, b)
+	}
+
+	if _, err := qb.UnmarshalQuery([]byte(`{"type":"Nope"}`)); err == nil {
+		t.Error("expected an error decoding an unknown type")
+	}
+	for _, b := range []string{
+		`{"type":"expr","SQL":"1=1; DROP TABLE x"}`,
+		`{"type":"keywordClause","Keyword":"DROP TABLE x;","Query":{"type":"BoolClause","Value":true}}`,
+	} {
+		if _, err := qb.UnmarshalQuery([]byte(b)); err == nil {
+			t.Errorf("expected an error decoding %s", b)
+		}
+	}
+	var sq qb.SelectQuery
+	if err := json.Unmarshal([]byte(`{"type":"DeleteQuery","Table":"x"}`), &sq); err == nil {
+		t.Error("expected an error decoding a mismatched type")
+	}
+}
+
+// normalizeValues converts ints to the int64s produced by decoding JSON.
+func normalizeValues(vals []interface{}) []interface{} {
+	if vals == nil {
+		return nil
+	}
+	out := make([]interface{}, len(vals))
+	for i, v := range vals {
+		if n, ok := v.(int); ok {
+			v = int64(n)
+		}
+		out[i] = v
+	}
+	return out
+}
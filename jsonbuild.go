@@ -0,0 +1,119 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JSONBuildObject returns an expression that resolves to the form
+// `json_build_object('key', value, ...)` on Postgres. Add the keys with Field
+// or Columns.
+func JSONBuildObject() JSONObjectClause {
+	return JSONObjectClause{Func: "json_build_object"}
+}
+
+// JSONObject is like JSONBuildObject, but resolves to the MySQL form
+// `JSON_OBJECT('key', value, ...)`.
+func JSONObject() JSONObjectClause {
+	return JSONObjectClause{Func: "JSON_OBJECT"}
+}
+
+// JSONAgg returns an aggregate expression that resolves to the form
+// `json_agg(expr)` on Postgres, collecting the expression for every row into a
+// JSON array. The order of the array can be set with Sort.
+func JSONAgg(q Query) AggregateClause {
+	return AggregateClause{
+		Func: "json_agg",
+		Expr: q,
+	}
+}
+
+// JSONArrayAgg is like JSONAgg, but resolves to the MySQL form
+// `JSON_ARRAYAGG(expr)`. MySQL doesn't support ordering the input, so Sort
+// shouldn't be used.
+func JSONArrayAgg(q Query) AggregateClause {
+	return AggregateClause{
+		Func: "JSON_ARRAYAGG",
+		Expr: q,
+	}
+}
+
+// RowToJSON returns an expression that resolves to the form `row_to_json(rel)`
+// on Postgres, converting an entire row of the named table or alias to a JSON
+// object.
+func RowToJSON(rel string) Query {
+	return RowToJSONExpr{Rel: rel}
+}
+
+// RowToJSONExpr represents the conversion of a whole row to a JSON object.
+type RowToJSONExpr struct {
+	Rel string
+}
+
+// Build returns an expression of the form `row_to_json(rel)`.
+func (e RowToJSONExpr) Build() string {
+	return fmt.Sprintf("row_to_json(%s)", e.Rel)
+}
+
+func (e RowToJSONExpr) String() string {
+	return e.Build()
+}
+
+// Values always returns nil for RowToJSONExpr.
+func (e RowToJSONExpr) Values() []interface{} {
+	return nil
+}
+
+func (e RowToJSONExpr) scalar() {}
+
+// JSONObjectClause represents a call to a function that builds a JSON object
+// from alternating keys and values. The keys are rendered as string literals,
+// since both Postgres and MySQL need to know their type when the query is
+// prepared, and should only come from trusted input.
+type JSONObjectClause struct {
+	Func  string
+	Keys  []string
+	Exprs []Query
+}
+
+// Field adds a key to the object. The value is rendered in place, like the
+// value of a comparison, so a Col is used as-is and a query is wrapped in
+// parentheses.
+func (c JSONObjectClause) Field(key string, value Query) JSONObjectClause {
+	c.Keys = append(c.Keys[:len(c.Keys):len(c.Keys)], key)
+	c.Exprs = append(c.Exprs[:len(c.Exprs):len(c.Exprs)], value)
+	return c
+}
+
+// Columns adds a key for each column, named after the column without its
+// table.
+func (c JSONObjectClause) Columns(columns ...string) JSONObjectClause {
+	for _, col := range columns {
+		c = c.Field(unqualified(col), Col(col))
+	}
+	return c
+}
+
+// Build returns an expression of the form `fn('key', value, ...)`.
+func (c JSONObjectClause) Build() string {
+	args := make([]string, 0, 2*len(c.Keys))
+	for i, key := range c.Keys {
+		args = append(args, quoteLiteral(key), buildArg(c.Exprs[i]))
+	}
+	return fmt.Sprintf("%s(%s)", c.Func, strings.Join(args, ", "))
+}
+
+func (c JSONObjectClause) String() string {
+	return c.Build()
+}
+
+// Values returns the values of each value expression in order.
+func (c JSONObjectClause) Values() []interface{} {
+	var vals []interface{}
+	for _, q := range c.Exprs {
+		vals = append(vals, argValues(q)...)
+	}
+	return vals
+}
+
+func (c JSONObjectClause) scalar() {}
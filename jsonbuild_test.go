@@ -0,0 +1,51 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestJSONBuild(t *testing.T) {
+	owner := qb.Select("people", "name").Where(qb.Equal("people.id", qb.Col("vehicles.owner_id")))
+
+	testcases := []testcase{
+		testcase{
+			name: "build object",
+			query: qb.Select("vehicles").Expr(qb.As(
+				qb.JSONBuildObject().Columns("vehicles.id", "make").Field("owner", owner),
+				"doc",
+			)),
+			want: output{
+				query: `SELECT json_build_object('id', vehicles.id, 'make', make, 'owner', (SELECT name FROM people WHERE people.id = vehicles.owner_id)) AS doc FROM vehicles`,
+			},
+		},
+		testcase{
+			name: "aggregate",
+			query: qb.Select("vehicles").
+				Expr(qb.As(qb.JSONAgg(qb.JSONBuildObject().Columns("id")).Sort(qb.OrderByClause{{Field: "id"}}), "docs")).
+				Where(qb.Equal("make", "Honda")),
+			want: output{
+				query: `SELECT json_agg(json_build_object('id', id) ORDER BY id) AS docs FROM vehicles WHERE make = ?`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name:  "mysql",
+			query: qb.Select("vehicles").Expr(qb.As(qb.JSONArrayAgg(qb.JSONObject().Columns("id", "make")), "docs")),
+			want: output{
+				query: `SELECT JSON_ARRAYAGG(JSON_OBJECT('id', id, 'make', make)) AS docs FROM vehicles`,
+			},
+		},
+		testcase{
+			name:  "row to json",
+			query: qb.Select("vehicles").Expr(qb.RowToJSON("vehicles")),
+			want: output{
+				query: `SELECT row_to_json(vehicles) FROM vehicles`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
@@ -0,0 +1,60 @@
+package qb
+
+import "fmt"
+
+// JSONPathExists returns a boolean clause that resolves to the form
+// `jsonb_path_exists(field, ?)`, which is true if the SQL/JSON path returns any
+// item for the document in the column. The path is bound as a parameter.
+// Requires Postgres 12 or later.
+func JSONPathExists(field, path string) JSONPathClause {
+	return JSONPathClause{
+		Func:  "jsonb_path_exists",
+		Field: field,
+		Path:  path,
+	}
+}
+
+// JSONPathQuery returns an expression that resolves to the form
+// `jsonb_path_query(field, ?)`, which returns a row for every item matched by
+// the path. Requires Postgres 12 or later.
+func JSONPathQuery(field, path string) JSONPathClause {
+	return JSONPathClause{
+		Func:  "jsonb_path_query",
+		Field: field,
+		Path:  path,
+	}
+}
+
+// JSONPathQueryFirst is like JSONPathQuery, but returns only the first item
+// matched, or NULL if there are none.
+func JSONPathQueryFirst(field, path string) JSONPathClause {
+	return JSONPathClause{
+		Func:  "jsonb_path_query_first",
+		Field: field,
+		Path:  path,
+	}
+}
+
+// JSONPathClause represents a call to one of the Postgres SQL/JSON path
+// functions.
+type JSONPathClause struct {
+	Func  string
+	Field string
+	Path  string
+}
+
+// Build returns an expression of the form `fn(field, ?)`.
+func (c JSONPathClause) Build() string {
+	return fmt.Sprintf("%s(%s, ?)", c.Func, c.Field)
+}
+
+func (c JSONPathClause) String() string {
+	return c.Build()
+}
+
+// Values returns the path.
+func (c JSONPathClause) Values() []interface{} {
+	return []interface{}{c.Path}
+}
+
+func (c JSONPathClause) scalar() {}
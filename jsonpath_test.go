@@ -0,0 +1,32 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestJSONPath(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name: "exists",
+			query: qb.Select("vehicles", "id").
+				Where(qb.And(qb.Equal("make", "Honda"), qb.JSONPathExists("specs", `$.engine ? (@.cylinders > 4)`))),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE (make = ? AND jsonb_path_exists(specs, ?))`,
+				vals:  []interface{}{"Honda", `$.engine ? (@.cylinders > 4)`},
+			},
+		},
+		testcase{
+			name:  "query",
+			query: qb.Select("vehicles").Expr(qb.As(qb.JSONPathQueryFirst("specs", "$.engine.cylinders"), "cylinders")),
+			want: output{
+				query: `SELECT jsonb_path_query_first(specs, ?) AS cylinders FROM vehicles`,
+				vals:  []interface{}{"$.engine.cylinders"},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
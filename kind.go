@@ -0,0 +1,44 @@
+package qb
+
+// Kind classifies a statement by what it does, so that middleware such as
+// read/write routing or metrics can branch on it without a type switch.
+type Kind string
+
+// The kinds of statement reported by Kind methods.
+const (
+	KindSelect Kind = "select"
+	KindInsert Kind = "insert"
+	KindUpdate Kind = "update"
+	KindDelete Kind = "delete"
+	KindDDL    Kind = "ddl"
+	KindRaw    Kind = "raw"
+)
+
+// KindOf returns the kind of q if it is a statement builder with a Kind method,
+// and KindRaw otherwise, since nothing is known about what it does.
+func KindOf(q Query) Kind {
+	if k, ok := q.(interface{ Kind() Kind }); ok {
+		return k.Kind()
+	}
+	return KindRaw
+}
+
+// ReadOnlyError is returned by CheckReadOnly for statements that may write.
+type ReadOnlyError struct {
+	Kind Kind
+}
+
+func (e *ReadOnlyError) Error() string {
+	return "qb: " + string(e.Kind) + " statement not allowed in read-only mode"
+}
+
+// CheckReadOnly returns a *ReadOnlyError unless q is a SELECT. It is intended
+// to guard connections to replicas or analytics services before a query is
+// executed. Raw statements and templates are rejected since what they do isn't
+// known.
+func CheckReadOnly(q Query) error {
+	if k := KindOf(q); k != KindSelect {
+		return &ReadOnlyError{Kind: k}
+	}
+	return nil
+}
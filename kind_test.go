@@ -0,0 +1,64 @@
+package qb_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestKind(t *testing.T) {
+	testcases := []struct {
+		query qb.Query
+		want  qb.Kind
+	}{
+		{qb.Select("vehicles"), qb.KindSelect},
+		{qb.Select("vehicles").Into("archive"), qb.KindDDL},
+		{qb.Select("vehicles").IntoTemp("scratch"), qb.KindDDL},
+		{qb.Join(qb.Select("a"), qb.Select("b")).On("a.id", "b.a_id"), qb.KindSelect},
+		{qb.CopyTo(qb.Select("vehicles")), qb.KindSelect},
+		{qb.Delete("vehicles"), qb.KindDelete},
+		{qb.Update("vehicles").Set("sold", true), qb.KindUpdate},
+		{qb.Explain(qb.Delete("vehicles")), qb.KindDelete},
+		{qb.Annotate(qb.Select("vehicles"), "report"), qb.KindSelect},
+		{qb.Raw("SELECT 1"), qb.KindRaw},
+		{qb.Template("SELECT 1"), qb.KindRaw},
+		{qb.Grant("SELECT").OnTable("vehicles").To("reporting"), qb.KindDDL},
+		{qb.CreateRole("reporting"), qb.KindDDL},
+		{qb.CreateSequence("ids"), qb.KindDDL},
+		{qb.CreateTableAs("archive", qb.Select("vehicles")), qb.KindDDL},
+		{qb.CreateIndex("vehicles_make_idx", "vehicles", "make"), qb.KindDDL},
+		{qb.Equal("make", "Honda"), qb.KindRaw},
+	}
+	for _, tc := range testcases {
+		if got := qb.KindOf(tc.query); got != tc.want {
+			t.Errorf("%s: wanted %s, got %s", tc.query.Build(), tc.want, got)
+		}
+	}
+}
+
+func TestCheckReadOnly(t *testing.T) {
+	if err := qb.CheckReadOnly(qb.Explain(qb.Select("vehicles"))); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := qb.CheckReadOnly(qb.Delete("vehicles"))
+	var roErr *qb.ReadOnlyError
+	if !errors.As(err, &roErr) {
+		t.Fatalf("expected a *ReadOnlyError, got %v", err)
+	}
+	if roErr.Kind != qb.KindDelete {
+		t.Errorf("wanted kind %s, got %s", qb.KindDelete, roErr.Kind)
+	}
+	if want := "qb: delete statement not allowed in read-only mode"; err.Error() != want {
+		t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", want, err)
+	}
+
+	if err := qb.CheckReadOnly(qb.Select("vehicles").IntoTemp("scratch")); err == nil {
+		t.Error("expected SELECT INTO to be rejected")
+	}
+
+	if err := qb.CheckReadOnly(qb.Raw("SELECT 1")); err == nil {
+		t.Error("expected raw statements to be rejected")
+	}
+}
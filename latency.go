@@ -0,0 +1,180 @@
+package qb
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fingerprint returns a short, stable identifier for the shape of a query.
+// Values bound to placeholders aren't part of the built query string, so
+// queries that differ only in those values share a fingerprint. Some builders
+// write values into the string itself, such as the literals of CopyTo and
+// Interval, and each distinct value of those has a fingerprint of its own.
+// Comments, such as those added by Annotate, are ignored.
+func Fingerprint(q Query) string {
+	sum := sha256.Sum256([]byte(normalize(q.Build())))
+	return hex.EncodeToString(sum[:8])
+}
+
+// normalize removes the comments from sql and collapses whitespace.
+func normalize(sql string) string {
+	return strings.Join(strings.Fields(stripComments(sql)), " ")
+}
+
+// stripComments replaces every comment in sql with a space, leaving quoted
+// strings and quoted identifiers alone.
+func stripComments(sql string) string {
+	var sb strings.Builder
+	for i := 0; i < len(sql); i++ {
+		switch c := sql[i]; {
+		case c == '\'' || c == '"':
+			start := i
+			for i++; i < len(sql) && sql[i] != c; i++ {
+			}
+			if i < len(sql) {
+				sb.WriteString(sql[start : i+1])
+			} else {
+				sb.WriteString(sql[start:])
+			}
+		case c == '-' && i+1 < len(sql) && sql[i+1] == '-':
+			for i++; i < len(sql) && sql[i] != '\n'; i++ {
+			}
+			sb.WriteByte(' ')
+		case c == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			for i += 2; i+1 < len(sql) && !(sql[i] == '*' && sql[i+1] == '/'); i++ {
+			}
+			i++
+			sb.WriteByte(' ')
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// DefaultLatencyBuckets are the upper bounds of the histogram buckets used by a
+// LatencyRecorder when none are specified.
+var DefaultLatencyBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// QueryStats is the latency summary for a single query fingerprint.
+type QueryStats struct {
+	Fingerprint string
+	SQL         string
+	Count       int
+	Slow        int
+	Total       time.Duration
+	Max         time.Duration
+
+	// Buckets holds the number of executions that completed within each of the
+	// recorder's bucket bounds, with a final bucket for everything slower.
+	Buckets []int
+}
+
+// Mean returns the average duration of the recorded executions.
+func (s QueryStats) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// NewLatencyRecorder returns a recorder that counts executions taking longer
+// than threshold as slow.
+func NewLatencyRecorder(threshold time.Duration) *LatencyRecorder {
+	return &LatencyRecorder{
+		Threshold: threshold,
+		Bounds:    DefaultLatencyBuckets,
+		stats:     make(map[string]*QueryStats),
+	}
+}
+
+// LatencyRecorder aggregates query durations by fingerprint. It is meant to be
+// called by whatever executes queries after each execution, and is safe for
+// concurrent use. A LatencyRecorder implements expvar.Var, so it can be
+// published directly with expvar.Publish.
+type LatencyRecorder struct {
+	Threshold time.Duration
+	Bounds    []time.Duration
+
+	mu    sync.Mutex
+	stats map[string]*QueryStats
+}
+
+// Record adds a single execution of q that took d.
+func (r *LatencyRecorder) Record(q Query, d time.Duration) {
+	fp := Fingerprint(q)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.stats[fp]
+	if !ok {
+		s = &QueryStats{
+			Fingerprint: fp,
+			SQL:         normalize(q.Build()),
+			Buckets:     make([]int, len(r.Bounds)+1),
+		}
+		r.stats[fp] = s
+	}
+	s.Count++
+	s.Total += d
+	if d > s.Max {
+		s.Max = d
+	}
+	if r.Threshold > 0 && d > r.Threshold {
+		s.Slow++
+	}
+	i := sort.Search(len(r.Bounds), func(i int) bool { return d <= r.Bounds[i] })
+	s.Buckets[i]++
+}
+
+// Snapshot returns a copy of the current statistics, slowest mean first.
+func (r *LatencyRecorder) Snapshot() []QueryStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshot := make([]QueryStats, 0, len(r.stats))
+	for _, s := range r.stats {
+		cp := *s
+		cp.Buckets = append([]int(nil), s.Buckets...)
+		snapshot = append(snapshot, cp)
+	}
+	sort.Slice(snapshot, func(i, j int) bool {
+		if snapshot[i].Mean() != snapshot[j].Mean() {
+			return snapshot[i].Mean() > snapshot[j].Mean()
+		}
+		return snapshot[i].Fingerprint < snapshot[j].Fingerprint
+	})
+	return snapshot
+}
+
+// Reset discards all recorded statistics. Taking a Snapshot and then calling
+// Reset on an interval gives a rolling view of recent query latency.
+func (r *LatencyRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats = make(map[string]*QueryStats)
+}
+
+// String returns the current snapshot as JSON, satisfying expvar.Var.
+func (r *LatencyRecorder) String() string {
+	b, err := json.Marshal(r.Snapshot())
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
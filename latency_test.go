@@ -0,0 +1,70 @@
+package qb_test
+
+import (
+	"expvar"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/haleyrc/qb"
+)
+
+var _ expvar.Var = &qb.LatencyRecorder{}
+
+func TestFingerprint(t *testing.T) {
+	a := qb.Select("vehicles", "id").Where(qb.Equal("make", "Honda"))
+	b := qb.Select("vehicles", "id").Where(qb.Equal("make", "Toyota"))
+	c := qb.Select("vehicles", "id").Where(qb.Equal("model", "Civic"))
+
+	if qb.Fingerprint(a) != qb.Fingerprint(b) {
+		t.Error("expected queries differing only in values to share a fingerprint")
+	}
+	if qb.Fingerprint(a) == qb.Fingerprint(c) {
+		t.Error("expected queries with different shapes to have different fingerprints")
+	}
+	if qb.Fingerprint(qb.Annotate(a, "request_id=1")) != qb.Fingerprint(qb.Annotate(b, "request_id=2")) {
+		t.Error("expected queries differing only in comments to share a fingerprint")
+	}
+	d := qb.Raw("SELECT id FROM vehicles WHERE make = '--' -- trailing\n")
+	e := qb.Raw("SELECT id FROM vehicles WHERE make = '/*'")
+	if qb.Fingerprint(d) == qb.Fingerprint(e) {
+		t.Error("expected comment markers in strings to be kept")
+	}
+}
+
+func TestLatencyRecorder(t *testing.T) {
+	r := qb.NewLatencyRecorder(100 * time.Millisecond)
+	fast := qb.Select("vehicles", "id").Where(qb.Equal("make", "Honda"))
+	slow := qb.Select("photos")
+
+	r.Record(fast, 2*time.Millisecond)
+	r.Record(qb.Select("vehicles", "id").Where(qb.Equal("make", "Toyota")), 4*time.Millisecond)
+	r.Record(slow, 200*time.Millisecond)
+	r.Record(slow, 10*time.Second)
+
+	got := r.Snapshot()
+	if len(got) != 2 {
+		t.Fatalf("wanted 2 fingerprints, got %d", len(got))
+	}
+
+	want := qb.QueryStats{
+		Fingerprint: qb.Fingerprint(slow),
+		SQL:         "SELECT * FROM photos",
+		Count:       2,
+		Slow:        2,
+		Total:       10200 * time.Millisecond,
+		Max:         10 * time.Second,
+		Buckets:     []int{0, 0, 0, 0, 0, 1, 0, 0, 1},
+	}
+	if !reflect.DeepEqual(got[0], want) {
+		t.Errorf("\n\twanted:\n%+v\n\tgot:\n%+v", want, got[0])
+	}
+	if got[1].Count != 2 || got[1].Slow != 0 || got[1].Mean() != 3*time.Millisecond {
+		t.Errorf("unexpected stats for fast query: %+v", got[1])
+	}
+
+	r.Reset()
+	if got := r.Snapshot(); len(got) != 0 {
+		t.Errorf("wanted no stats after reset, got %d", len(got))
+	}
+}
@@ -0,0 +1,112 @@
+package qb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// NewLimiter returns a limiter that allows at most n queries in flight at once.
+// n should be somewhat lower than the size of the connection pool, so that
+// bursts of queries from one caller leave connections for everyone else. It
+// panics if n isn't positive, since no query could ever run.
+func NewLimiter(n int) *Limiter {
+	if n <= 0 {
+		panic(fmt.Sprintf("qb: NewLimiter called with %d slots; at least one is required", n))
+	}
+	return &Limiter{
+		sem: make(chan struct{}, n),
+	}
+}
+
+// Limiter caps the number of concurrently executing queries and records how
+// long callers waited for a slot. Like LatencyRecorder, it is meant to be
+// called by whatever executes queries, and is safe for concurrent use.
+type Limiter struct {
+	sem chan struct{}
+
+	mu    sync.Mutex
+	stats LimiterStats
+}
+
+// LimiterStats summarizes the activity of a Limiter.
+type LimiterStats struct {
+	// InFlight is the number of slots currently held.
+	InFlight int
+
+	// Waiting is the number of callers currently waiting for a slot.
+	Waiting int
+
+	// Acquired is the total number of slots handed out.
+	Acquired int
+
+	// Canceled is the number of callers that gave up waiting because their
+	// context was done.
+	Canceled int
+
+	// TotalWait and MaxWait describe the time spent waiting for the acquired
+	// slots.
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// Acquire waits for a slot to become available, and returns a function that
+// must be called to release it once the query has finished. If ctx is done
+// before a slot is available, its error is returned instead.
+func (l *Limiter) Acquire(ctx context.Context) (func(), error) {
+	start := time.Now()
+	l.update(func(s *LimiterStats) { s.Waiting++ })
+
+	select {
+	case l.sem <- struct{}{}:
+	case <-ctx.Done():
+		l.update(func(s *LimiterStats) {
+			s.Waiting--
+			s.Canceled++
+		})
+		return nil, ctx.Err()
+	}
+
+	wait := time.Since(start)
+	l.update(func(s *LimiterStats) {
+		s.Waiting--
+		s.InFlight++
+		s.Acquired++
+		s.TotalWait += wait
+		if wait > s.MaxWait {
+			s.MaxWait = wait
+		}
+	})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-l.sem
+			l.update(func(s *LimiterStats) { s.InFlight-- })
+		})
+	}, nil
+}
+
+// Do calls fn while holding a slot.
+func (l *Limiter) Do(ctx context.Context, fn func() error) error {
+	release, err := l.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return fn()
+}
+
+// Stats returns a snapshot of the limiter's activity.
+func (l *Limiter) Stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+func (l *Limiter) update(fn func(*LimiterStats)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fn(&l.stats)
+}
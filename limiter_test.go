@@ -0,0 +1,61 @@
+package qb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestLimiter(t *testing.T) {
+	l := qb.NewLimiter(1)
+	ctx := context.Background()
+
+	release, err := l.Acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.Acquire(timeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to time out, got %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- l.Do(ctx, func() error { return nil })
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if s := l.Stats(); s.Waiting != 1 || s.InFlight != 1 {
+		t.Errorf("expected one waiting and one in flight, got %+v", s)
+	}
+	release()
+	release()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	s := l.Stats()
+	if s.InFlight != 0 || s.Waiting != 0 || s.Acquired != 2 || s.Canceled != 1 {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+	if s.MaxWait < 10*time.Millisecond {
+		t.Errorf("expected the queued call to have waited, got %s", s.MaxWait)
+	}
+}
+
+func TestNewLimiterWithoutSlots(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected NewLimiter(%d) to panic", n)
+				}
+			}()
+			qb.NewLimiter(n)
+		}()
+	}
+}
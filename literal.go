@@ -0,0 +1,93 @@
+package qb
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// inline builds q with every value rendered as an SQL literal in place of its
+// placeholder. It is only used for statements that can't take bound
+// parameters, and for output that is meant to be reviewed by people.
+func inline(q Query) string {
+	sql := q.Build()
+	vals := q.Values()
+
+	var sb strings.Builder
+	last, n := 0, 0
+	scanPlaceholders(sql, func(offset int) {
+		sb.WriteString(sql[last:offset])
+		if n < len(vals) {
+			sb.WriteString(literal(vals[n]))
+		} else {
+			sb.WriteString("?")
+		}
+		n++
+		last = offset + 1
+	})
+	sb.WriteString(sql[last:])
+	return sb.String()
+}
+
+// literal returns v as an SQL literal. Values of types that don't have a
+// literal form of their own are rendered as quoted strings, so the result is
+// always safe to embed in a statement.
+func literal(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int:
+		return strconv.FormatInt(int64(v), 10)
+	case int8:
+		return strconv.FormatInt(int64(v), 10)
+	case int16:
+		return strconv.FormatInt(int64(v), 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float32:
+		return formatFloat(float64(v), 32)
+	case float64:
+		return formatFloat(v, 64)
+	case string:
+		return quoteLiteral(v)
+	case []byte:
+		return quoteLiteral(`\x` + hex.EncodeToString(v))
+	case time.Time:
+		return quoteLiteral(v.Format(time.RFC3339Nano))
+	case driver.Valuer:
+		dv, err := v.Value()
+		if err != nil {
+			return quoteLiteral(fmt.Sprint(v))
+		}
+		return literal(dv)
+	}
+	return quoteLiteral(fmt.Sprint(v))
+}
+
+func formatFloat(f float64, bits int) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return quoteLiteral(strconv.FormatFloat(f, 'g', -1, bits))
+	}
+	return strconv.FormatFloat(f, 'g', -1, bits)
+}
@@ -0,0 +1,305 @@
+package qb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrCannotMatch is returned by Match for clauses that can't be evaluated
+// outside of the database, such as subqueries.
+var ErrCannotMatch = errors.New("clause can't be evaluated in memory")
+
+// Match evaluates a tree of comparison and boolean clauses against an
+// in-memory record, so the same filter can be applied to cached objects and
+// used in SQL. Fields are looked up in row by name; qualified fields such as
+// `vehicles.make` fall back to the bare column name if the qualified name isn't
+// present.
+//
+// As in SQL, comparisons involving nil are never true. Numeric values of
+// different types are compared as numbers, but otherwise both sides of a
+// comparison must have the same type. LIKE patterns are matched case
+// sensitively against strings.
+func Match(q Query, row map[string]interface{}) (bool, error) {
+	switch q := q.(type) {
+	case BooleanQuery:
+		lhs, err := Match(q.Comparison1, row)
+		if err != nil {
+			return false, err
+		}
+		rhs, err := Match(q.Comparison2, row)
+		if err != nil {
+			return false, err
+		}
+		switch q.Op {
+		case "AND":
+			return lhs && rhs, nil
+		case "OR":
+			return lhs || rhs, nil
+		}
+		return false, fmt.Errorf("qb: %w: unknown operator %s", ErrCannotMatch, q.Op)
+	case ComparisonClause:
+		return matchComparison(q, row)
+	case AnyClause:
+		return matchAny(q, row)
+	case InClause:
+		return matchIn(q, row)
+	case BetweenClause:
+		return matchBetween(q, row)
+	case NullClause:
+		v, err := lookupField(row, q.Field)
+		if err != nil {
+			return false, err
+		}
+		return (v == nil) != q.Not, nil
+	case NotClause:
+		return matchNot(q, row)
+	case BoolClause:
+		return bool(q), nil
+	case Filter:
+		return Match(q.Cond, row)
+	}
+	return false, fmt.Errorf("qb: %w: %T", ErrCannotMatch, q)
+}
+
+func matchComparison(c ComparisonClause, row map[string]interface{}) (bool, error) {
+	lhs, err := lookupField(row, c.Field)
+	if err != nil {
+		return false, err
+	}
+	rhs := valueOf(c.Value)
+	switch v := rhs.(type) {
+	case Column:
+		if rhs, err = lookupField(row, string(v)); err != nil {
+			return false, err
+		}
+	case Query:
+		return false, fmt.Errorf("qb: %w: subquery in comparison on %s", ErrCannotMatch, c.Field)
+	}
+	if lhs == nil || rhs == nil {
+		return false, nil
+	}
+	if c.Op == "LIKE" || c.Op == "NOT LIKE" {
+		s, ok1 := lhs.(string)
+		pattern, ok2 := rhs.(string)
+		if !ok1 || !ok2 {
+			return false, fmt.Errorf("qb: %w: %s on %T and %T", ErrCannotMatch, c.Op, lhs, rhs)
+		}
+		return matchLike(s, pattern) == (c.Op == "LIKE"), nil
+	}
+
+	cmp, err := compareValues(lhs, rhs)
+	if err != nil {
+		return false, fmt.Errorf("qb: compare %s: %w", c.Field, err)
+	}
+	switch c.Op {
+	case "=":
+		return cmp == 0, nil
+	case "!=", "<>":
+		return cmp != 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	}
+	return false, fmt.Errorf("qb: %w: unknown operator %s", ErrCannotMatch, c.Op)
+}
+
+// matchNot evaluates a negation by pushing it down to the comparisons, which
+// keeps the SQL behaviour of NULLs: NOT (a = NULL) isn't true either.
+func matchNot(c NotClause, row map[string]interface{}) (bool, error) {
+	var field string
+	switch q := c.Query.(type) {
+	case InClause:
+		field = q.Field
+	case AnyClause:
+		field = q.Field
+	default:
+		neg, err := negate(c.Query)
+		if err != nil {
+			return false, err
+		}
+		return Match(neg, row)
+	}
+	// A list can't be negated element by element, so the NULL check is done
+	// here instead.
+	lhs, err := lookupField(row, field)
+	if err != nil || lhs == nil {
+		return false, err
+	}
+	ok, err := Match(c.Query, row)
+	return !ok, err
+}
+
+// negate returns the condition that is true exactly when q is false.
+func negate(q Query) (Query, error) {
+	switch q := q.(type) {
+	case ComparisonClause:
+		op, ok := negations[q.Op]
+		if !ok {
+			return nil, fmt.Errorf("qb: %w: unknown operator %s", ErrCannotMatch, q.Op)
+		}
+		q.Op = op
+		return q, nil
+	case BooleanQuery:
+		lhs, err := negate(q.Comparison1)
+		if err != nil {
+			return nil, err
+		}
+		rhs, err := negate(q.Comparison2)
+		if err != nil {
+			return nil, err
+		}
+		switch q.Op {
+		case "AND":
+			return Or(lhs, rhs), nil
+		case "OR":
+			return And(lhs, rhs), nil
+		}
+		return nil, fmt.Errorf("qb: %w: unknown operator %s", ErrCannotMatch, q.Op)
+	case BetweenClause:
+		q.Not = !q.Not
+		return q, nil
+	case NullClause:
+		q.Not = !q.Not
+		return q, nil
+	case BoolClause:
+		return !q, nil
+	case NotClause:
+		return q.Query, nil
+	case Filter:
+		return negate(q.Cond)
+	case InClause, AnyClause:
+		return Not(q), nil
+	}
+	return nil, fmt.Errorf("qb: %w: %T", ErrCannotMatch, q)
+}
+
+func lookupField(row map[string]interface{}, field string) (interface{}, error) {
+	if v, ok := row[field]; ok {
+		return v, nil
+	}
+	if i := strings.LastIndex(field, "."); i >= 0 {
+		if v, ok := row[field[i+1:]]; ok {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("qb: no field %s in row", field)
+}
+
+// compareValues returns -1, 0 or 1 depending on whether a is less than, equal
+// to or greater than b.
+func compareValues(a, b interface{}) (int, error) {
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			return compareOrdered(x, y), nil
+		}
+	}
+	switch x := a.(type) {
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), nil
+		}
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			switch {
+			case x.Before(y):
+				return -1, nil
+			case x.After(y):
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			if x == y {
+				return 0, nil
+			}
+			if !x {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, fmt.Errorf("can't compare %T with %T", a, b)
+}
+
+func compareOrdered(x, y float64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// matchLike reports whether s matches a LIKE pattern, using backslash as the
+// escape character. Matching is case sensitive, as in Postgres.
+func matchLike(s, pattern string) bool {
+	return likeRunes([]rune(s), []rune(pattern))
+}
+
+func likeRunes(s, p []rune) bool {
+	for len(p) > 0 {
+		switch {
+		case p[0] == '%':
+			for len(p) > 0 && p[0] == '%' {
+				p = p[1:]
+			}
+			for i := 0; i <= len(s); i++ {
+				if likeRunes(s[i:], p) {
+					return true
+				}
+			}
+			return false
+		case len(s) == 0:
+			return false
+		case p[0] == '_':
+		case p[0] == '\\' && len(p) > 1:
+			if s[0] != p[1] {
+				return false
+			}
+			p = p[1:]
+		case s[0] != p[0]:
+			return false
+		}
+		s, p = s[1:], p[1:]
+	}
+	return len(s) == 0
+}
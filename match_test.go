@@ -0,0 +1,86 @@
+package qb_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestMatch(t *testing.T) {
+	now := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	row := map[string]interface{}{
+		"make":       "Honda",
+		"cost":       int64(15000),
+		"msrp":       18000.0,
+		"created_at": now,
+		"sold":       false,
+		"notes":      nil,
+	}
+
+	testcases := []struct {
+		name  string
+		query qb.Query
+		want  bool
+	}{
+		{"equal", qb.Equal("make", "Honda"), true},
+		{"not equal", qb.Equal("make", "Toyota"), false},
+		{"mixed numbers", qb.Less("cost", 20000), true},
+		{"greater equal", qb.GreaterEqual("cost", 15000.0), true},
+		{"time", qb.Greater("created_at", now.Add(-time.Hour)), true},
+		{"bool", qb.Equal("sold", false), true},
+		{"null", qb.Equal("notes", nil), false},
+		{"column", qb.Less("cost", qb.Col("msrp")), true},
+		{"qualified", qb.Equal("vehicles.make", "Honda"), true},
+		{"and", qb.And(qb.Equal("make", "Honda"), qb.Greater("cost", 20000)), false},
+		{"or", qb.Or(qb.Equal("make", "Toyota"), qb.LessEqual("cost", 15000)), true},
+		{"not equal op", qb.NotEqual("make", "Toyota"), true},
+		{"like", qb.Like("make", "H%d_"), true},
+		{"like escaped", qb.Like("make", `H\%`), false},
+		{"not like", qb.NotLike("make", "%yota"), true},
+		{"between", qb.Between("cost", 10000, 15000), true},
+		{"not between", qb.NotBetween("cost", 10000, 15000), false},
+		{"not between null", qb.NotBetween("notes", 1, 2), false},
+		{"not", qb.Not(qb.And(qb.Equal("make", "Honda"), qb.Greater("cost", 20000))), true},
+		{"not null", qb.Not(qb.Equal("notes", "x")), false},
+		{"not in", qb.Not(qb.In("make", "Toyota", "Ford")), true},
+		{"not not", qb.Not(qb.Not(qb.Equal("make", "Honda"))), true},
+		{"eq", qb.Eq(map[string]interface{}{"make": []string{"Honda", "Acura"}, "notes": nil}), true},
+		{"not eq null", qb.Not(qb.Eq(map[string]interface{}{"notes": nil})), false},
+	}
+	for _, tc := range testcases {
+		got, err := qb.Match(tc.query, row)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: wanted %t, got %t", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestMatchErrors(t *testing.T) {
+	row := map[string]interface{}{"make": "Honda", "cost": 15000}
+
+	if _, err := qb.Match(qb.Equal("vin", "123"), row); err == nil {
+		t.Error("expected an error for a missing field")
+	}
+	if _, err := qb.Match(qb.Equal("make", 1), row); err == nil {
+		t.Error("expected an error for mismatched types")
+	}
+	sub := qb.Equal("cost", qb.Select("prices", "max"))
+	if _, err := qb.Match(sub, row); !errors.Is(err, qb.ErrCannotMatch) {
+		t.Errorf("expected ErrCannotMatch for a subquery, got %v", err)
+	}
+	if ok, err := qb.Match(qb.In("make", "Toyota", "Honda"), row); err != nil || !ok {
+		t.Errorf("expected IN to match, got %v, %v", ok, err)
+	}
+	if ok, err := qb.Match(qb.In("make", []string{"Toyota"}), row); err != nil || ok {
+		t.Errorf("expected IN not to match, got %v, %v", ok, err)
+	}
+	if _, err := qb.Match(qb.In("make"), row); !errors.Is(err, qb.ErrCannotMatch) {
+		t.Errorf("expected ErrCannotMatch for IN, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package qb_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestVersionedRoundTrip(t *testing.T) {
+	q := qb.Select("vehicles", "id").Where(qb.And(qb.Equal("make", "Honda"), qb.Less("cost", 20000)))
+
+	b, err := qb.MarshalVersioned(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var envelope struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(b, &envelope); err != nil {
+		t.Fatal(err)
+	}
+	if envelope.Version != qb.SchemaVersion {
+		t.Errorf("wanted version %d, got %d", qb.SchemaVersion, envelope.Version)
+	}
+
+	got, err := qb.UnmarshalVersioned(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Build() != q.Build() {
+		t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", q.Build(), got.Build())
+	}
+}
+
+func TestUnmarshalVersionedUnversioned(t *testing.T) {
+	queries := []qb.Query{
+		qb.Equal("make", "Honda"),
+		qb.Not(qb.Equal("make", "Honda")),
+	}
+	for _, q := range queries {
+		b, err := json.Marshal(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := qb.UnmarshalVersioned(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Build() != q.Build() {
+			t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", q.Build(), got.Build())
+		}
+	}
+}
+
+func TestUnmarshalVersionedFuture(t *testing.T) {
+	doc := `{"version": 1000, "query": {"type": "Column", "Name": "id"}}`
+	if _, err := qb.UnmarshalVersioned([]byte(doc)); err == nil {
+		t.Error("expected an error decoding a future schema version")
+	}
+}
+
+func TestUnmarshalVersionedMigratesRoleKind(t *testing.T) {
+	doc := `{"version": 1, "query": {"type": "RoleQuery", "Kind": "USER", "Name": "reporting", "Options": ["LOGIN"]}}`
+	if _, err := qb.UnmarshalVersioned([]byte(doc)); err == nil {
+		t.Error("expected an error decoding a role without trust")
+	}
+	got, err := qb.UnmarshalTrustedVersioned([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `CREATE USER reporting WITH LOGIN`; got.Build() != want {
+		t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", want, got.Build())
+	}
+}
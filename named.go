@@ -0,0 +1,38 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NamedArgs rewrites the positional placeholders of q to named arguments of the
+// form `@p1`, as accepted by pgx v5, and returns the rewritten statement along
+// with the values keyed by name. The map can be passed to pgx by converting it
+// with pgx.NamedArgs(args).
+//
+// An error wrapping ErrPlaceholderMismatch is returned if the number of
+// placeholders doesn't match the number of values.
+func NamedArgs(q Query) (string, map[string]interface{}, error) {
+	sql := q.Build()
+	vals := q.Values()
+
+	var sb strings.Builder
+	args := make(map[string]interface{}, len(vals))
+	last, n := 0, 0
+	scanPlaceholders(sql, func(offset int) {
+		n++
+		name := fmt.Sprintf("p%d", n)
+		if n <= len(vals) {
+			args[name] = vals[n-1]
+		}
+		sb.WriteString(sql[last:offset])
+		sb.WriteString("@" + name)
+		last = offset + 1
+	})
+	sb.WriteString(sql[last:])
+
+	if n != len(vals) {
+		return "", nil, fmt.Errorf("qb: %w: %d placeholder(s) but %d value(s) in %s", ErrPlaceholderMismatch, n, len(vals), sql)
+	}
+	return sb.String(), args, nil
+}
@@ -0,0 +1,42 @@
+package qb_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestNamedArgs(t *testing.T) {
+	q := qb.Select("vehicles", "id").Where(qb.And(
+		qb.Equal("make", "Honda"),
+		qb.Less("cost", 20000),
+	))
+	sql, args, err := qb.NamedArgs(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `SELECT id FROM vehicles WHERE (make = @p1 AND cost < @p2)`; sql != want {
+		t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", want, sql)
+	}
+	if want := map[string]interface{}{"p1": "Honda", "p2": 20000}; !reflect.DeepEqual(args, want) {
+		t.Errorf("\n\twanted:\n%v\n\tgot:\n%v", want, args)
+	}
+
+	sql, args, err = qb.NamedArgs(qb.Raw(`SELECT '?' AS q, id FROM vehicles WHERE make = ?`, "Honda"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `SELECT '?' AS q, id FROM vehicles WHERE make = @p1`; sql != want {
+		t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", want, sql)
+	}
+	if len(args) != 1 {
+		t.Errorf("expected one argument, got %v", args)
+	}
+
+	_, _, err = qb.NamedArgs(qb.Raw(`SELECT id FROM vehicles WHERE make = ?`))
+	if !errors.Is(err, qb.ErrPlaceholderMismatch) {
+		t.Errorf("expected a placeholder mismatch, got %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package qb
+
+import "strings"
+
+// Notify returns a query that resolves to `SELECT pg_notify(?, ?)`, sending a
+// notification with the payload to every session listening on the channel.
+// Using pg_notify rather than NOTIFY allows both arguments to be bound as
+// parameters.
+func Notify(channel, payload string) RawQuery {
+	return Raw("SELECT pg_notify(?, ?)", channel, payload)
+}
+
+// Listen returns a query that resolves to `LISTEN "channel"`. LISTEN doesn't
+// accept parameters, so the channel is quoted as an identifier instead, which
+// also keeps its case consistent with the channel name given to Notify.
+func Listen(channel string) RawQuery {
+	return Raw("LISTEN " + quoteIdent(channel))
+}
+
+// Unlisten returns a query that resolves to `UNLISTEN "channel"`.
+func Unlisten(channel string) RawQuery {
+	return Raw("UNLISTEN " + quoteIdent(channel))
+}
+
+// quoteIdent returns s as a double-quoted SQL identifier.
+func quoteIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
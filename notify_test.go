@@ -0,0 +1,37 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestNotify(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name:  "notify",
+			query: qb.Notify("vehicle_updates", `{"id":5}`),
+			want: output{
+				query: `SELECT pg_notify(?, ?)`,
+				vals:  []interface{}{"vehicle_updates", `{"id":5}`},
+			},
+		},
+		testcase{
+			name:  "listen",
+			query: qb.Listen("Vehicle Updates"),
+			want: output{
+				query: `LISTEN "Vehicle Updates"`,
+			},
+		},
+		testcase{
+			name:  "unlisten with quote",
+			query: qb.Unlisten(`a"b`),
+			want: output{
+				query: `UNLISTEN "a""b"`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
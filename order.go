@@ -0,0 +1,234 @@
+package qb
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Direction is the direction of a single ORDER BY term.
+type Direction string
+
+// The supported sort directions.
+const (
+	Asc  Direction = "ASC"
+	Desc Direction = "DESC"
+)
+
+// OrderByExpr returns an ORDER BY term that sorts by an arbitrary expression rather
+// than a column, for example a CASE expression or a function call with bound
+// arguments.
+func OrderByExpr(q Query, dir Direction) Order {
+	return Order{
+		Expr: q,
+		Dir:  dir,
+	}
+}
+
+// OrderByCollate returns an ascending ORDER BY term that sorts the field using
+// the named collation, e.g. an ICU collation such as `und-x-icu` on Postgres,
+// so that user-facing alphabetical sorts follow the rules of a locale rather
+// than those of the database.
+func OrderByCollate(field, collation string) Order {
+	return Order{
+		Field:     field,
+		Dir:       Asc,
+		Collation: collation,
+	}
+}
+
+// RandomExpr represents a random number generator seeded with Seed, used to
+// order rows randomly but repeatably. See SelectQuery.OrderByRandomSeed.
+type RandomExpr struct {
+	Seed float64
+
+	dialect Dialect
+}
+
+// Build returns an expression of the form `(SELECT 0 FROM setseed(?)) +
+// random()`. The seed is set by the subquery, which Postgres runs once before
+// random is called for the first row. On MySQL, the form is `RAND(?)`, with the
+// seed scaled to an integer. If the expression is built for a dialect whose
+// random function can't be seeded, it falls back to the unseeded function used
+// by OrderByRandomFor.
+func (e RandomExpr) Build() string {
+	switch dialectName(e.dialect) {
+	case "mysql":
+		return "RAND(?)"
+	case "sqlserver":
+		return "NEWID()"
+	case "sqlite":
+		return "RANDOM()"
+	}
+	return "(SELECT 0 FROM setseed(?)) + random()"
+}
+
+func (e RandomExpr) String() string {
+	return e.Build()
+}
+
+// Values returns the seed, if the dialect uses one.
+func (e RandomExpr) Values() []interface{} {
+	switch dialectName(e.dialect) {
+	case "mysql":
+		return []interface{}{int64(e.Seed * math.MaxInt32)}
+	case "sqlserver", "sqlite":
+		return nil
+	}
+	return []interface{}{e.Seed}
+}
+
+func (e RandomExpr) scalar() {}
+
+func (e RandomExpr) withDialect(d Dialect) interface{} {
+	e.dialect = d
+	return e
+}
+
+// Order represents a single ORDER BY term of the form `field [direction]`.
+type Order struct {
+	Field string
+	Dir   Direction
+
+	// Expr is an expression to sort by. If it is set, it is used in place of
+	// Field.
+	Expr Query
+
+	// NullsLast sorts NULLs after every other value regardless of direction,
+	// using the form `field direction NULLS LAST`. MySQL and SQL Server don't
+	// support NULLS LAST, so when the term is built for them it is emulated
+	// with a leading `CASE WHEN field IS NULL THEN 1 ELSE 0 END` term.
+	NullsLast bool
+
+	// Collation is the name of the collation to sort with, if not the default.
+	Collation string
+
+	dialect Dialect
+}
+
+// Build returns a term of the form `field direction`, or just `field` if no
+// direction was specified. A collation is added as `field COLLATE "name"`.
+// With NullsLast, the term is followed by `NULLS LAST`, or preceded by `CASE
+// WHEN field IS NULL THEN 1 ELSE 0 END, ` for dialects that don't support it.
+func (o Order) Build() string {
+	field := o.Field
+	if o.Expr != nil {
+		field = o.Expr.Build()
+	}
+	term := field
+	if o.Collation != "" {
+		term = fmt.Sprintf("%s COLLATE %s", term, quoteIdent(o.Collation))
+	}
+	if o.Dir != "" {
+		term = fmt.Sprintf("%s %s", term, o.Dir)
+	}
+	if o.NullsLast && o.emulateNulls() {
+		return fmt.Sprintf("CASE WHEN %s IS NULL THEN 1 ELSE 0 END, %s", field, term)
+	}
+	if o.NullsLast {
+		return term + " NULLS LAST"
+	}
+	return term
+}
+
+// emulateNulls reports whether NullsLast has to be emulated for the dialect of
+// the term.
+func (o Order) emulateNulls() bool {
+	switch dialectName(o.dialect) {
+	case "mysql", "sqlserver":
+		return true
+	}
+	return false
+}
+
+func (o Order) withDialect(d Dialect) interface{} {
+	o.dialect = d
+	return o
+}
+
+func (o Order) String() string {
+	return o.Build()
+}
+
+// Values returns the values of the expression being sorted by, if any.
+func (o Order) Values() []interface{} {
+	if o.Expr == nil {
+		return nil
+	}
+	if o.NullsLast && o.emulateNulls() {
+		vals := o.Expr.Values()
+		return append(vals[:len(vals):len(vals)], vals...)
+	}
+	return o.Expr.Values()
+}
+
+// WithNullsLast returns a copy of the term that sorts NULLs last. See
+// Order.NullsLast.
+func (o Order) WithNullsLast() Order {
+	o.NullsLast = true
+	return o
+}
+
+// OrderByClause represents the list of terms in an ORDER BY clause.
+type OrderByClause []Order
+
+// Build returns the comma-separated list of terms without the leading `ORDER
+// BY` so the clause can be embedded wherever an ordering is allowed.
+func (c OrderByClause) Build() string {
+	terms := make([]string, 0, len(c))
+	for _, o := range c {
+		terms = append(terms, o.Build())
+	}
+	return strings.Join(terms, ", ")
+}
+
+func (c OrderByClause) String() string {
+	return c.Build()
+}
+
+// Values returns the aggregate of the values for each term.
+func (c OrderByClause) Values() []interface{} {
+	var vals []interface{}
+	for _, o := range c {
+		vals = append(vals, o.Values()...)
+	}
+	return vals
+}
+
+// ParseSort converts a sort parameter of the form `-created_at,name`, as
+// commonly accepted by list endpoints, into an ORDER BY clause. Fields are
+// separated by commas and sorted in ascending order unless they are prefixed
+// with `-`. A `+` prefix is accepted for explicit ascending order. Every field
+// must be present in allowed, which also maps it to the column to sort by.
+func ParseSort(sort string, allowed FieldMap) (OrderByClause, error) {
+	if strings.TrimSpace(sort) == "" {
+		return nil, nil
+	}
+
+	var clause OrderByClause
+	for _, term := range strings.Split(sort, ",") {
+		field, dir := parseSortTerm(term)
+		if field == "" {
+			return nil, fmt.Errorf("qb: empty field in sort %q", sort)
+		}
+		column, err := allowed.Column(field)
+		if err != nil {
+			return nil, err
+		}
+		clause = append(clause, Order{Field: column, Dir: dir})
+	}
+	return clause, nil
+}
+
+// parseSortTerm splits a single sort term of the form `-field` or `+field` into
+// the field and its direction.
+func parseSortTerm(term string) (string, Direction) {
+	term = strings.TrimSpace(term)
+	switch {
+	case strings.HasPrefix(term, "-"):
+		return term[1:], Desc
+	case strings.HasPrefix(term, "+"):
+		return term[1:], Asc
+	}
+	return term, Asc
+}
@@ -0,0 +1,218 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestParseSort(t *testing.T) {
+	sort, err := qb.ParseSort("-createdAt, +make,id", vehicleFields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	empty, err := qb.ParseSort("", vehicleFields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:  "sorted select",
+			query: qb.Select("vehicles", "id").Where(qb.Equal("make", "Honda")).Sort(sort),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE make = ? ORDER BY created_at DESC, make ASC, id ASC`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name:  "random order",
+			query: qb.Select("vehicles", "id").OrderByRandom(),
+			want: output{
+				query: `SELECT id FROM vehicles ORDER BY RANDOM()`,
+			},
+		},
+		testcase{
+			name:  "random order for mysql",
+			query: qb.Select("vehicles", "id").OrderByRandomFor(qb.MySQL),
+			want: output{
+				query: `SELECT id FROM vehicles ORDER BY RAND()`,
+			},
+		},
+		testcase{
+			name:  "random order for sql server",
+			query: qb.Select("vehicles", "id").OrderByRandomFor(qb.SQLServer),
+			want: output{
+				query: `SELECT id FROM vehicles ORDER BY NEWID()`,
+			},
+		},
+		testcase{
+			name:  "empty sort",
+			query: qb.Select("vehicles", "id").Sort(empty),
+			want: output{
+				query: `SELECT id FROM vehicles`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+
+	for _, bad := range []string{"vin", "-", "id,,make"} {
+		if _, err := qb.ParseSort(bad, vehicleFields); err == nil {
+			t.Errorf("expected an error parsing %q", bad)
+		}
+	}
+}
+
+func TestOrderByRandomSeed(t *testing.T) {
+	postgres, err := qb.Select("vehicles", "id").Limit(10).OrderByRandomSeed(qb.Postgres, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mysql, err := qb.Select("vehicles", "id").Limit(10).OrderByRandomSeed(qb.MySQL, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:  "postgres",
+			query: postgres,
+			want: output{
+				query: `SELECT id FROM vehicles ORDER BY (SELECT 0 FROM setseed(?)) + random() LIMIT ?`,
+				vals:  []interface{}{0.5, 10},
+			},
+		},
+		testcase{
+			name:  "mysql",
+			query: mysql,
+			want: output{
+				query: `SELECT id FROM vehicles ORDER BY RAND(?) LIMIT ?`,
+				vals:  []interface{}{int64(1073741823), 10},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+
+	for _, d := range []qb.Dialect{qb.SQLite, qb.SQLServer} {
+		if _, err := qb.Select("vehicles", "id").OrderByRandomSeed(d, 0.5); err == nil {
+			t.Errorf("%s: expected an error", d.Name())
+		}
+	}
+	if _, err := qb.Select("vehicles", "id").OrderByRandomSeed(qb.Postgres, 2); err == nil {
+		t.Error("expected an error for a seed out of range")
+	}
+}
+
+func TestNullsLast(t *testing.T) {
+	field := qb.Select("vehicles", "id").Sort(qb.OrderByClause{
+		qb.Order{Field: "sold_at", Dir: qb.Desc}.WithNullsLast(),
+		{Field: "id"},
+	})
+	expr := qb.Select("vehicles", "id").Sort(qb.OrderByClause{
+		qb.OrderByExpr(qb.Raw("NULLIF(make, ?)", ""), qb.Asc).WithNullsLast(),
+	})
+
+	testcases := []struct {
+		dialect qb.Dialect
+		testcase
+	}{
+		{qb.Postgres, testcase{
+			name:  "field",
+			query: field,
+			want: output{
+				query: `SELECT id FROM vehicles ORDER BY sold_at DESC NULLS LAST, id`,
+			},
+		}},
+		{qb.Postgres, testcase{
+			name:  "expression",
+			query: expr,
+			want: output{
+				query: `SELECT id FROM vehicles ORDER BY NULLIF(make, $1) ASC NULLS LAST`,
+				vals:  []interface{}{""},
+			},
+		}},
+		{qb.MySQL, testcase{
+			name:  "field",
+			query: field,
+			want: output{
+				query: `SELECT id FROM vehicles ORDER BY CASE WHEN sold_at IS NULL THEN 1 ELSE 0 END, sold_at DESC, id`,
+			},
+		}},
+		{qb.MySQL, testcase{
+			name:  "expression",
+			query: expr,
+			want: output{
+				query: `SELECT id FROM vehicles ORDER BY CASE WHEN NULLIF(make, ?) IS NULL THEN 1 ELSE 0 END, NULLIF(make, ?) ASC`,
+				vals:  []interface{}{"", ""},
+			},
+		}},
+		{qb.SQLServer, testcase{
+			name:  "field",
+			query: field,
+			want: output{
+				query: `SELECT id FROM vehicles ORDER BY CASE WHEN sold_at IS NULL THEN 1 ELSE 0 END, sold_at DESC, id`,
+			},
+		}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.dialect.Name()+"/"+tc.name, testFor(tc.dialect, tc.testcase))
+	}
+
+	want := output{query: `SELECT id FROM vehicles ORDER BY sold_at DESC NULLS LAST, id`}
+	t.Run("build", test(testcase{query: field, want: want}))
+}
+
+func TestOrderByCollate(t *testing.T) {
+	desc := qb.OrderByCollate("name", "sv-x-icu")
+	desc.Dir = qb.Desc
+
+	testcases := []testcase{
+		testcase{
+			name:  "ascending",
+			query: qb.Select("people", "name").Sort(qb.OrderByClause{qb.OrderByCollate("name", "und-x-icu")}),
+			want: output{
+				query: `SELECT name FROM people ORDER BY name COLLATE "und-x-icu" ASC`,
+			},
+		},
+		testcase{
+			name:  "descending",
+			query: qb.Select("people", "name").Sort(qb.OrderByClause{desc}),
+			want: output{
+				query: `SELECT name FROM people ORDER BY name COLLATE "sv-x-icu" DESC`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name: "chained",
+			query: qb.Select("vehicles", "id").
+				Where(qb.Equal("make", "Honda")).
+				OrderBy("created_at", qb.Desc).
+				OrderBy("id", qb.Asc),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE make = ? ORDER BY created_at DESC, id ASC`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name:  "no direction",
+			query: qb.Select("vehicles", "id").OrderBy("id", ""),
+			want: output{
+				query: `SELECT id FROM vehicles ORDER BY id`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
@@ -0,0 +1,61 @@
+package qb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrSelectStar is reported by VerifyProjection for queries that select `*`.
+var ErrSelectStar = errors.New("query selects * instead of an explicit field list")
+
+// VerifyProjection reports an error wrapping ErrSelectStar if q returns the
+// results of a SELECT without an explicit field list, either because no fields
+// were given or because a field is `*` or `table.*`. Selecting `*` returns
+// whatever columns the table has at the time, so adding a column can break
+// scanning or leak data. Statements that wrap a select, such as EXPLAIN or
+// CREATE TABLE AS, are checked too, while subqueries in conditions are not.
+//
+// VerifyProjection is intended to be run on every query in production, where
+// callers can choose to reject offending queries or only log them. Errors are
+// always of type *Error.
+func VerifyProjection(q Query) error {
+	return verifyProjection(q, nil)
+}
+
+func verifyProjection(q Query, path []string) error {
+	switch q := q.(type) {
+	case SelectQuery:
+		return verifyFields(q.scoped(), path)
+	case JoinQuery:
+		for i, sq := range q.scoped().queries() {
+			if err := verifyFields(sq, at(path, fmt.Sprintf("join[%d]", i))); err != nil {
+				return err
+			}
+		}
+	case CreateTableAsQuery:
+		return verifyProjection(q.Query, at(path, "as"))
+	case ExplainQuery:
+		return verifyProjection(q.Query, path)
+	case AnnotatedQuery:
+		return verifyProjection(q.Query, path)
+	case CopyQuery:
+		return verifyProjection(q.Query, at(path, "copy"))
+	}
+	return nil
+}
+
+func verifyFields(q SelectQuery, path []string) error {
+	if len(q.Fields) == 0 && len(q.Exprs) == 0 {
+		return &Error{Path: path, Err: fmt.Errorf("%w: %s", ErrSelectStar, q.Table)}
+	}
+	for i, field := range q.Fields {
+		if field == "*" || strings.HasSuffix(field, ".*") {
+			return &Error{
+				Path: at(path, fmt.Sprintf("field[%d]", i)),
+				Err:  fmt.Errorf("%w: %s", ErrSelectStar, field),
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package qb_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestVerifyProjection(t *testing.T) {
+	valid := []qb.Query{
+		qb.Select("vehicles", "id", "make"),
+		qb.Select("vehicles").Expr(qb.As(qb.Raw("COUNT(*)"), "n")),
+		qb.Select("vehicles", "COUNT(*)"),
+		qb.Join(qb.Select("employees", "id"), qb.Select("dealerships", "name")).On("employees.dealership_id", "dealerships.id"),
+		qb.Delete("vehicles"),
+		qb.Select("photos", "url").Where(qb.Equal("vehicle_id", qb.Select("vehicles"))),
+	}
+	for _, q := range valid {
+		if err := qb.VerifyProjection(q); err != nil {
+			t.Errorf("%s: unexpected error: %v", q.Build(), err)
+		}
+	}
+
+	invalid := map[string]qb.Query{
+		"qb: query selects * instead of an explicit field list: vehicles":             qb.Select("vehicles"),
+		"qb: field[1]: query selects * instead of an explicit field list: v.*":        qb.Select("vehicles", "id", "v.*"),
+		"qb: join[1]: query selects * instead of an explicit field list: dealerships": qb.Join(qb.Select("employees", "id"), qb.Select("dealerships")).On("a", "b"),
+		"qb: as: query selects * instead of an explicit field list: vehicles":         qb.CreateTableAs("copy", qb.Select("vehicles")),
+		"qb: copy: query selects * instead of an explicit field list: vehicles":       qb.CopyTo(qb.Explain(qb.Select("vehicles"))),
+	}
+	for want, q := range invalid {
+		err := qb.VerifyProjection(q)
+		if !errors.Is(err, qb.ErrSelectStar) {
+			t.Errorf("%s: expected ErrSelectStar, got %v", q.Build(), err)
+			continue
+		}
+		if err.Error() != want {
+			t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", want, err)
+		}
+	}
+}
@@ -1,9 +1,9 @@
 package qb
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 )
 
 // Query is the primary interface our components must implement.
@@ -19,32 +19,45 @@ type Query interface {
 	Values() []interface{}
 }
 
-// In returns a new IN clause that resolves to the form `field IN (?)`.
-func In(field string) InClause {
-	return InClause(field)
+// In returns a new IN clause that resolves to the form `field IN (?, ?)`, with
+// one placeholder per value.
+//
+// Without any values, the clause resolves to `field IN (?)` and the values are
+// left to be bound by the caller, which is how sqlx.In expects to find it. The
+// same is true of a single slice value, so `In("make", makes)` can be passed
+// straight to sqlx.In for expansion.
+func In(field string, values ...interface{}) InClause {
+	return InClause{
+		Field: field,
+		Vals:  values,
+	}
 }
 
 // InClause represents an SQL query where a column value can be one of multiple
-// potential values. Currently this is the only query type that doesn't retain
-// any comparison values, but since we have to rebind the query as a client
-// anyway, we can probably extend this to include them.
-//
-// TODO (RCH): Add the comparison values
-type InClause string
+// potential values.
+type InClause struct {
+	Field string
+	Vals  []interface{}
+}
 
-// Build returns an IN clause of the form `field IN (?)`.
+// Build returns an IN clause of the form `field IN (?, ?)`, or `field IN (?)`
+// if there are no values.
 func (c InClause) Build() string {
-	return fmt.Sprintf("%s IN (?)", string(c))
+	n := len(c.Vals)
+	if n == 0 {
+		n = 1
+	}
+	return fmt.Sprintf("%s IN (%s)", c.Field, placeholders(n))
 }
 
 func (c InClause) String() string {
 	return c.Build()
 }
 
-// Values returns nil since we don't store the comparison values for IN clauses
-// in the current implementation.
+// Values returns the values in the list, which is nil if they are to be bound
+// by the caller.
 func (c InClause) Values() []interface{} {
-	return nil
+	return c.Vals
 }
 
 // Greater returns a boolean clause that resolves to the form `(field > value)`.
@@ -94,6 +107,77 @@ func Equal(field string, value interface{}) ComparisonClause {
 	}
 }
 
+// NotEqual returns a boolean clause that resolves to the form
+// `(field != value)`.
+func NotEqual(field string, value interface{}) ComparisonClause {
+	return ComparisonClause{
+		Op:    "!=",
+		Field: field,
+		Value: value,
+	}
+}
+
+// Like returns a boolean clause that resolves to the form `(field LIKE
+// pattern)`, where % in the pattern matches any sequence of characters and _
+// matches any single character. Use a backslash to match them literally.
+func Like(field string, pattern interface{}) ComparisonClause {
+	return ComparisonClause{
+		Op:    "LIKE",
+		Field: field,
+		Value: pattern,
+	}
+}
+
+// NotLike returns a boolean clause that resolves to the form `(field NOT LIKE
+// pattern)`.
+func NotLike(field string, pattern interface{}) ComparisonClause {
+	return ComparisonClause{
+		Op:    "NOT LIKE",
+		Field: field,
+		Value: pattern,
+	}
+}
+
+// IsNull returns a boolean clause that resolves to the form `field IS NULL`.
+func IsNull(field string) NullClause {
+	return NullClause{
+		Field: field,
+	}
+}
+
+// IsNotNull returns a boolean clause that resolves to the form `field IS NOT
+// NULL`.
+func IsNotNull(field string) NullClause {
+	return NullClause{
+		Field: field,
+		Not:   true,
+	}
+}
+
+// NullClause represents a test of whether a field is NULL.
+type NullClause struct {
+	Field string
+	Not   bool
+}
+
+// Build returns an expression of the form `field IS NULL`, or `field IS NOT
+// NULL` if negated.
+func (c NullClause) Build() string {
+	if c.Not {
+		return c.Field + " IS NOT NULL"
+	}
+	return c.Field + " IS NULL"
+}
+
+func (c NullClause) String() string {
+	return c.Build()
+}
+
+// Values always returns nil for NullClause.
+func (c NullClause) Values() []interface{} {
+	return nil
+}
+
 // ComparisonClause represents a binary boolean expression. Comparison clauses
 // are automatically surrounded by parentheses to prevent order-of-operations
 // issues in the resulting query.
@@ -119,7 +203,11 @@ type ComparisonClause struct {
 // `(field op value)` in the case of simple values, or `(field op (subquery))`
 // if the value is a Query.
 func (c ComparisonClause) Build() string {
-	if q, ok := c.Value.(Query); ok {
+	v := valueOf(c.Value)
+	if e, ok := v.(scalar); ok {
+		return fmt.Sprintf("%s %s %s", c.Field, c.Op, e.(Query).Build())
+	}
+	if q, ok := v.(Query); ok {
 		return fmt.Sprintf("%s %s (%s)", c.Field, c.Op, q.Build())
 	}
 	return fmt.Sprintf("%s %s ?", c.Field, c.Op)
@@ -132,10 +220,108 @@ func (c ComparisonClause) String() string {
 // Values returns the RHS value in the case of simple expressions. If the value
 // is a query, it returns the values for that subquery instead.
 func (c ComparisonClause) Values() []interface{} {
-	if q, ok := c.Value.(Query); ok {
+	v := valueOf(c.Value)
+	if q, ok := v.(Query); ok {
 		return q.Values()
 	}
-	return []interface{}{c.Value}
+	return []interface{}{v}
+}
+
+// Col returns a column reference that resolves to the bare column name. When
+// used as the value of a comparison, the column is compared directly rather
+// than bound as a parameter, which allows correlated subqueries of the form
+// `photos.vehicle_id = vehicles.id`.
+func Col(name string) Column {
+	return Column(name)
+}
+
+// Column represents a reference to a column, optionally qualified with its
+// table name.
+type Column string
+
+// Build returns the column name as-is.
+func (c Column) Build() string {
+	return string(c)
+}
+
+func (c Column) String() string {
+	return c.Build()
+}
+
+// Values always returns nil for Column.
+func (c Column) Values() []interface{} {
+	return nil
+}
+
+func (c Column) scalar() {}
+
+// Bool returns a constant boolean expression that resolves to `TRUE` or
+// `FALSE`. It is mostly useful as the starting point for filters that are
+// assembled in a loop, and is removed again by Simplify.
+func Bool(b bool) BoolClause {
+	return BoolClause(b)
+}
+
+// BoolClause represents a boolean literal.
+type BoolClause bool
+
+// Build returns either `TRUE` or `FALSE`.
+func (c BoolClause) Build() string {
+	if c {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+func (c BoolClause) String() string {
+	return c.Build()
+}
+
+// Values always returns nil for BoolClause.
+func (c BoolClause) Values() []interface{} {
+	return nil
+}
+
+func (c BoolClause) scalar() {}
+
+// scalar is implemented by expressions that never need to be wrapped in
+// parentheses when they are embedded in a larger query.
+type scalar interface {
+	scalar()
+}
+
+// As returns an expression that resolves to the form `(expr) AS alias`. Column
+// references and other simple expressions are not wrapped in parentheses.
+func As(q Query, alias string) AliasClause {
+	return AliasClause{
+		Query: q,
+		Alias: alias,
+	}
+}
+
+// AliasClause represents a named expression in the field list of a SELECT,
+// most commonly a scalar subquery.
+type AliasClause struct {
+	Query Query
+	Alias string
+}
+
+// Build returns an expression of the form `(expr) AS alias`, or `expr AS
+// alias` if the aliased query is a simple expression such as a Column.
+func (c AliasClause) Build() string {
+	if _, ok := c.Query.(scalar); ok {
+		return fmt.Sprintf("%s AS %s", c.Query.Build(), c.Alias)
+	}
+	return fmt.Sprintf("(%s) AS %s", c.Query.Build(), c.Alias)
+}
+
+func (c AliasClause) String() string {
+	return c.Build()
+}
+
+// Values returns the values for the aliased expression.
+func (c AliasClause) Values() []interface{} {
+	return c.Query.Values()
 }
 
 // Or returns a boolean query that resolves to the form `(expr OR expr)`.
@@ -182,6 +368,32 @@ func (q BooleanQuery) Values() []interface{} {
 	return append(vals, q.Comparison2.Values()...)
 }
 
+// Not returns a boolean query that resolves to the form `NOT (expr)`.
+func Not(q Query) NotClause {
+	return NotClause{
+		Query: q,
+	}
+}
+
+// NotClause represents the negation of a boolean expression.
+type NotClause struct {
+	Query Query
+}
+
+// Build returns an expression of the form `NOT (expr)`.
+func (c NotClause) Build() string {
+	return fmt.Sprintf("NOT (%s)", c.Query.Build())
+}
+
+func (c NotClause) String() string {
+	return c.Build()
+}
+
+// Values returns the values of the negated expression.
+func (c NotClause) Values() []interface{} {
+	return c.Query.Values()
+}
+
 // Delete returns a query that resolves to the general form `DELETE FROM table
 // [WHERE expr]`.
 func Delete(table string) DeleteQuery {
@@ -196,35 +408,109 @@ type DeleteQuery struct {
 	Table       string
 	Vals        []interface{}
 	WhereClause Query
+	Ordering    OrderByClause
+	LimitRows   int
+
+	dialect Dialect
 }
 
-// Build returns a query string of the form `DELETE FROM table [WHERE expr]`.
+// Build returns a query string of the form `DELETE FROM table [WHERE expr]
+// [ORDER BY terms] [LIMIT ?]`. SQL Server doesn't support either clause in a
+// DELETE, so a limited delete built for it deletes from a common table
+// expression instead, of the form `WITH limited AS (SELECT TOP (?) * FROM table
+// [WHERE expr] [ORDER BY terms]) DELETE FROM limited`.
 func (q DeleteQuery) Build() string {
+	if q.LimitRows > 0 && dialectName(q.dialect) == "sqlserver" {
+		sel := fmt.Sprintf("SELECT TOP (?) * FROM %s", q.Table)
+		if q.WhereClause != nil {
+			sel += fmt.Sprintf(" WHERE %s", q.WhereClause.Build())
+		}
+		if len(q.Ordering) > 0 {
+			sel += fmt.Sprintf(" ORDER BY %s", q.Ordering.Build())
+		}
+		return fmt.Sprintf("WITH limited AS (%s) DELETE FROM limited", sel)
+	}
 	stmt := fmt.Sprintf("DELETE FROM %s", q.Table)
 	if q.WhereClause != nil {
 		stmt += fmt.Sprintf(" WHERE %s", q.WhereClause.Build())
 	}
+	if len(q.Ordering) > 0 {
+		stmt += fmt.Sprintf(" ORDER BY %s", q.Ordering.Build())
+	}
+	if q.LimitRows > 0 {
+		stmt += " LIMIT ?"
+	}
 	return stmt
 }
 
 func (q DeleteQuery) String() string {
-	b, err := json.MarshalIndent(q, "", "    ")
-	if err != nil {
-		return ""
-	}
-	return string(b)
+	return Dump(q)
 }
 
-// Values returns the accumulated values for the query and any subqueries.
+// Values returns the accumulated values for the query and any subqueries,
+// followed by the limit if one was set. When built for SQL Server, the limit
+// comes first instead.
 func (q DeleteQuery) Values() []interface{} {
-	return q.Vals
+	if q.LimitRows > 0 && dialectName(q.dialect) == "sqlserver" {
+		vals := append([]interface{}{q.LimitRows}, q.Vals...)
+		return append(vals, q.Ordering.Values()...)
+	}
+	vals := append(q.Vals[:len(q.Vals):len(q.Vals)], q.Ordering.Values()...)
+	if q.LimitRows > 0 {
+		vals = append(vals, q.LimitRows)
+	}
+	return vals
+}
+
+func (q DeleteQuery) withDialect(d Dialect) interface{} {
+	q.dialect = d
+	return q
+}
+
+// ToSql is like SelectQuery.ToSql.
+func (q DeleteQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(q)
+}
+
+// BuildFor is like SelectQuery.BuildFor.
+func (q DeleteQuery) BuildFor(d Dialect) string {
+	return BuildFor(q, d)
+}
+
+// Kind returns KindDelete.
+func (q DeleteQuery) Kind() Kind {
+	return KindDelete
+}
+
+// Sort appends the terms of an ORDER BY clause to the ordering of the query.
+// Ordered deletes are only supported by MySQL and are mostly useful together
+// with Limit.
+func (q DeleteQuery) Sort(o OrderByClause) DeleteQuery {
+	q.Ordering = append(q.Ordering[:len(q.Ordering):len(q.Ordering)], o...)
+	return q
+}
+
+// Limit caps the number of rows deleted by the query using the MySQL form
+// `DELETE ... LIMIT ?`. Deleting a large number of rows in bounded batches
+// avoids holding locks on the whole set at once. A limit of zero removes the
+// cap.
+func (q DeleteQuery) Limit(n int) DeleteQuery {
+	q.LimitRows = n
+	return q
 }
 
 // Where adds an additional WHERE clause condition to the query that will be
-// evaluated and injected into the final query string.
+// evaluated and injected into the final query string. Calling Where again
+// combines the conditions with AND.
 func (q DeleteQuery) Where(wq Query) DeleteQuery {
-	q.WhereClause = wq
-	q.Vals = append(q.Vals, wq.Values()...)
+	q.WhereClause, q.Vals = andWhere(q.WhereClause, q.Vals, wq)
+	return q
+}
+
+// OrWhere is like Where, but combines the condition with any existing WHERE
+// clause using OR.
+func (q DeleteQuery) OrWhere(wq Query) DeleteQuery {
+	q.WhereClause, q.Vals = orWhere(q.WhereClause, q.Vals, wq)
 	return q
 }
 
@@ -240,46 +526,422 @@ func Select(table string, fields ...string) SelectQuery {
 // SelectQuery represents a query that resolves to the general form `SELECT
 // fields FROM table [WHERE expr]`.
 type SelectQuery struct {
-	Table       string
-	Fields      []string
-	Vals        []interface{}
-	WhereClause Query
+	Table         string
+	Source        Query
+	Fields        []string
+	Exprs         []Query
+	Vals          []interface{}
+	WhereClause   Query
+	Groups        []string
+	HavingClause  Query
+	Ordering      OrderByClause
+	Partitions    []string
+	IntoTable     string
+	IntoTempTable bool
+	SampleMethod  string
+	SamplePercent float64
+	Hints         []string
+	SkipDefaults  bool
+	LimitRows     int
+	OffsetRows    int
+	AsOfTime      time.Time
+
+	dialect Dialect
 }
 
 // Build returns a query string of the general form `SELECT fields FROM table
 // [WHERE expr]`.
 func (q SelectQuery) Build() string {
-	var stmt string
-	if len(q.Fields) == 0 {
-		stmt = fmt.Sprintf("SELECT * FROM %s", q.Table)
-	} else {
-		fields := strings.Join(q.Fields, ", ")
-		stmt = fmt.Sprintf("SELECT %s FROM %s", fields, q.Table)
+	q = q.scoped()
+	fields := "*"
+	if len(q.Fields) > 0 || len(q.Exprs) > 0 {
+		list := append([]string{}, q.Fields...)
+		for _, expr := range q.Exprs {
+			list = append(list, expr.Build())
+		}
+		fields = strings.Join(list, ", ")
+	}
+	stmt := "SELECT "
+	if len(q.Hints) > 0 && q.hinted() {
+		stmt += fmt.Sprintf("/*+ %s */ ", sanitizeComment(strings.Join(q.Hints, " ")))
 	}
+	stmt += fields
+	if q.IntoTable != "" {
+		if q.IntoTempTable {
+			stmt += fmt.Sprintf(" INTO TEMP %s", q.IntoTable)
+		} else {
+			stmt += fmt.Sprintf(" INTO %s", q.IntoTable)
+		}
+	}
+	stmt += fmt.Sprintf(" FROM %s", q.from())
 	if q.WhereClause != nil {
 		stmt += fmt.Sprintf(" WHERE %s", q.WhereClause.Build())
 	}
+	if len(q.Groups) > 0 {
+		stmt += fmt.Sprintf(" GROUP BY %s", strings.Join(q.Groups, ", "))
+	}
+	if q.HavingClause != nil {
+		stmt += fmt.Sprintf(" HAVING %s", q.HavingClause.Build())
+	}
+	if len(q.Ordering) > 0 {
+		stmt += fmt.Sprintf(" ORDER BY %s", q.Ordering.Build())
+	}
+	if dialectName(q.dialect) == "sqlserver" {
+		return stmt + q.fetch()
+	}
+	if q.LimitRows > 0 {
+		stmt += " LIMIT ?"
+	}
+	if q.OffsetRows > 0 {
+		stmt += " OFFSET ?"
+	}
 	return stmt
 }
 
-func (q SelectQuery) String() string {
-	b, err := json.MarshalIndent(q, "", "    ")
-	if err != nil {
+// fetch returns the SQL Server form of the limit and offset, `OFFSET ? ROWS
+// [FETCH NEXT ? ROWS ONLY]`. SQL Server only accepts them after an ORDER BY
+// clause, so an ordering of `(SELECT NULL)` is added if the query isn't
+// ordered.
+func (q SelectQuery) fetch() string {
+	if q.LimitRows <= 0 && q.OffsetRows <= 0 {
 		return ""
 	}
-	return string(b)
+	stmt := ""
+	if len(q.Ordering) == 0 {
+		stmt += " ORDER BY (SELECT NULL)"
+	}
+	stmt += " OFFSET ? ROWS"
+	if q.LimitRows > 0 {
+		stmt += " FETCH NEXT ? ROWS ONLY"
+	}
+	return stmt
+}
+
+// hinted reports whether the hints of the query are rendered for its dialect.
+// SQL Server and SQLite don't read hint comments, so they are left out.
+func (q SelectQuery) hinted() bool {
+	switch dialectName(q.dialect) {
+	case "sqlserver", "sqlite":
+		return false
+	}
+	return true
+}
+
+func (q SelectQuery) withDialect(d Dialect) interface{} {
+	q.dialect = d
+	return q
+}
+
+func (q SelectQuery) String() string {
+	return Dump(q)
 }
 
 // Values returns the accumulated values for the query and any subqueries.
+// Values for expressions in the field list come first since they precede the
+// WHERE clause in the query string, followed by those for the HAVING clause
+// and the ORDER BY clause, and the limit and offset come last. When built for
+// SQL Server, the offset comes before the limit, as in the query string.
 func (q SelectQuery) Values() []interface{} {
-	return q.Vals
+	q = q.scoped()
+	vals := q.exprValues()
+	vals = append(vals, q.fromValues()...)
+	vals = append(vals, q.Vals...)
+	if q.HavingClause != nil {
+		vals = append(vals, q.HavingClause.Values()...)
+	}
+	vals = append(vals, q.Ordering.Values()...)
+	if dialectName(q.dialect) == "sqlserver" {
+		if q.LimitRows > 0 || q.OffsetRows > 0 {
+			vals = append(vals, q.OffsetRows)
+		}
+		if q.LimitRows > 0 {
+			vals = append(vals, q.LimitRows)
+		}
+		return vals
+	}
+	if q.LimitRows > 0 {
+		vals = append(vals, q.LimitRows)
+	}
+	if q.OffsetRows > 0 {
+		vals = append(vals, q.OffsetRows)
+	}
+	return vals
+}
+
+// ToSql returns the query string and values along with the result of Verify.
+// It allows the query to be passed to libraries that accept squirrel-style
+// builders.
+func (q SelectQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(q)
+}
+
+// BuildFor returns the query string written for the dialect, with
+// placeholders such as `$1` for Postgres. The values are returned by ValuesFor;
+// see BuildFor.
+func (q SelectQuery) BuildFor(d Dialect) string {
+	return BuildFor(q, d)
+}
+
+// Kind reports the kind of statement, which is KindSelect for SelectQuery
+// unless the results are written into a new table with Into or IntoTemp, in
+// which case it is KindDDL. Every statement builder has a Kind method; see
+// KindOf.
+func (q SelectQuery) Kind() Kind {
+	if q.IntoTable != "" {
+		return KindDDL
+	}
+	return KindSelect
+}
+
+func (q SelectQuery) exprValues() []interface{} {
+	var vals []interface{}
+	for _, expr := range q.Exprs {
+		vals = append(vals, expr.Values()...)
+	}
+	return vals
+}
+
+// from returns the table reference used in the FROM clause of the query.
+func (q SelectQuery) from() string {
+	from := q.Table
+	if q.Source != nil {
+		from = q.Source.Build()
+	}
+	if len(q.Partitions) > 0 {
+		from += fmt.Sprintf(" PARTITION (%s)", strings.Join(q.Partitions, ", "))
+	}
+	if !q.AsOfTime.IsZero() && q.Source == nil {
+		if meta, ok := LookupTable(q.Table); ok && meta.HistoryTable != "" {
+			period := meta.periodColumn()
+			from = fmt.Sprintf("(SELECT * FROM %[1]s WHERE %[2]s @> CAST(? AS timestamptz) UNION ALL SELECT * FROM %[3]s WHERE %[2]s @> CAST(? AS timestamptz)) AS %[1]s", q.Table, period, meta.HistoryTable)
+		} else {
+			from += " FOR SYSTEM_TIME AS OF ?"
+		}
+	}
+	if q.SampleMethod != "" {
+		from += fmt.Sprintf(" TABLESAMPLE %s (?)", q.SampleMethod)
+	}
+	return from
+}
+
+// fromValues returns the values for the table reference returned by from.
+func (q SelectQuery) fromValues() []interface{} {
+	var vals []interface{}
+	if q.Source != nil {
+		vals = append(vals, q.Source.Values()...)
+	}
+	if !q.AsOfTime.IsZero() && q.Source == nil {
+		vals = append(vals, q.AsOfTime)
+		if meta, ok := LookupTable(q.Table); ok && meta.HistoryTable != "" {
+			vals = append(vals, q.AsOfTime)
+		}
+	}
+	if q.SampleMethod != "" {
+		vals = append(vals, q.SamplePercent)
+	}
+	return vals
+}
+
+// TableSample restricts the query to a random sample of the table using the
+// form `FROM table TABLESAMPLE method (?)`, where the method is typically
+// BERNOULLI (a sample of rows) or SYSTEM (a sample of pages, which is faster
+// but less random) and the percentage is bound as a parameter.
+func (q SelectQuery) TableSample(method string, percent float64) SelectQuery {
+	q.SampleMethod = method
+	q.SamplePercent = percent
+	return q
+}
+
+// AsOf reads the table as it was at the given time using the SQL Server and
+// MariaDB form `FROM table FOR SYSTEM_TIME AS OF ?`. Tables registered with a
+// HistoryTable, as is usual for history kept by triggers on Postgres, are read
+// from the union of the table and its history instead, of the form `FROM
+// (SELECT * FROM table WHERE period @> ? UNION ALL SELECT * FROM history WHERE
+// period @> ?) AS table`. AsOf has no effect on queries from a Source.
+func (q SelectQuery) AsOf(t time.Time) SelectQuery {
+	q.AsOfTime = t
+	return q
+}
+
+// Partition restricts the query to the named partitions of the table using the
+// MySQL form `FROM table PARTITION (p1, p2)`. On Postgres, partitions are
+// tables in their own right and should be selected from directly instead.
+func (q SelectQuery) Partition(names ...string) SelectQuery {
+	q.Partitions = append(q.Partitions[:len(q.Partitions):len(q.Partitions)], names...)
+	return q
+}
+
+// OrderByRandom appends a random ordering term, `RANDOM()`, to the ordering of
+// the query. Combined with a LIMIT this selects random rows, although it
+// requires sorting the whole result set and so is slow on large tables. See
+// TableSample for an alternative. RANDOM() is only understood by Postgres and
+// SQLite; use OrderByRandomFor for other databases.
+func (q SelectQuery) OrderByRandom() SelectQuery {
+	return q.Sort(OrderByClause{{Field: "RANDOM()"}})
+}
+
+// OrderByRandomFor is like OrderByRandom, but uses the random function of the
+// dialect: `RAND()` for MySQL, `NEWID()` for SQL Server and `RANDOM()` for
+// everything else. The order isn't repeatable on any of them; see
+// OrderByRandomSeed for a repeatable order.
+func (q SelectQuery) OrderByRandomFor(d Dialect) SelectQuery {
+	fn := "RANDOM()"
+	switch d.Name() {
+	case "mysql":
+		fn = "RAND()"
+	case "sqlserver":
+		fn = "NEWID()"
+	}
+	return q.Sort(OrderByClause{{Field: fn}})
+}
+
+// OrderByRandomSeed is like OrderByRandomFor, but orders the rows in a
+// repeatable order determined by the seed, which must be between -1 and 1, so
+// that a random sample can be paged through or reproduced. See RandomExpr for
+// the forms used. Seeded ordering isn't supported on SQLite, whose random
+// function can't be seeded, or on SQL Server, where RAND(seed) returns the same
+// value for every row, and an error is returned for them.
+func (q SelectQuery) OrderByRandomSeed(d Dialect, seed float64) (SelectQuery, error) {
+	switch d.Name() {
+	case "postgres", "mysql":
+	default:
+		return SelectQuery{}, fmt.Errorf("qb: seeded random ordering isn't supported on %s", d.Name())
+	}
+	if seed < -1 || seed > 1 {
+		return SelectQuery{}, fmt.Errorf("qb: random seed %v is not between -1 and 1", seed)
+	}
+	return q.Sort(OrderByClause{{Expr: RandomExpr{Seed: seed, dialect: d}}}), nil
+}
+
+// Hint adds an optimizer hint to the query. Hints are rendered in a single
+// hint comment directly after the SELECT keyword e.g. `SELECT /*+ INDEX(v
+// idx_make) */ ...`, which is where MySQL and Oracle expect them. Characters
+// that aren't safe inside a comment are removed, as for Annotate. Hints are
+// left out when the query is built for SQL Server or SQLite, which don't read
+// them.
+func (q SelectQuery) Hint(hint string) SelectQuery {
+	q.Hints = append(q.Hints[:len(q.Hints):len(q.Hints)], hint)
+	return q
+}
+
+// Into writes the results of the query into a new table using the form
+// `SELECT fields INTO table FROM ...`.
+func (q SelectQuery) Into(table string) SelectQuery {
+	q.IntoTable = table
+	q.IntoTempTable = false
+	return q
+}
+
+// IntoTemp writes the results of the query into a new temporary table using
+// the form `SELECT fields INTO TEMP table FROM ...`.
+func (q SelectQuery) IntoTemp(table string) SelectQuery {
+	q.IntoTable = table
+	q.IntoTempTable = true
+	return q
+}
+
+// GroupBy adds fields to the GROUP BY clause of the query.
+func (q SelectQuery) GroupBy(fields ...string) SelectQuery {
+	q.Groups = append(q.Groups[:len(q.Groups):len(q.Groups)], fields...)
+	return q
+}
+
+// Having adds a condition on the groups of the query of the form `HAVING
+// expr`. Calling Having more than once combines the conditions using AND.
+func (q SelectQuery) Having(hq Query) SelectQuery {
+	if q.HavingClause != nil {
+		hq = And(q.HavingClause, hq)
+	}
+	q.HavingClause = hq
+	return q
+}
+
+// Sort appends the terms of an ORDER BY clause, such as one returned by
+// ParseSort, to the ordering of the query.
+func (q SelectQuery) Sort(o OrderByClause) SelectQuery {
+	q.Ordering = append(q.Ordering[:len(q.Ordering):len(q.Ordering)], o...)
+	return q
+}
+
+// Limit caps the number of rows returned by the query using the form `LIMIT ?`,
+// or `FETCH NEXT ? ROWS ONLY` when built for SQL Server. A limit of zero
+// removes the cap.
+func (q SelectQuery) Limit(n int) SelectQuery {
+	q.LimitRows = n
+	return q
+}
+
+// Offset skips the given number of rows using the form `OFFSET ?`, or `OFFSET ?
+// ROWS` when built for SQL Server. Together with Limit and a stable ordering
+// this pages through the results. An offset of zero removes it.
+func (q SelectQuery) Offset(n int) SelectQuery {
+	q.OffsetRows = n
+	return q
+}
+
+// OrderBy appends a single term to the ordering of the query. Calls can be
+// chained to sort by multiple columns e.g.
+//
+//	q.OrderBy("created_at", qb.Desc).OrderBy("id", qb.Asc)
+func (q SelectQuery) OrderBy(field string, dir Direction) SelectQuery {
+	return q.Sort(OrderByClause{{Field: field, Dir: dir}})
+}
+
+// AddFields appends fields to the field list of an existing query. Note that
+// adding fields to a query with an empty field list changes it from selecting
+// `*` to selecting only the added fields.
+func (q SelectQuery) AddFields(fields ...string) SelectQuery {
+	q.Fields = append(q.Fields[:len(q.Fields):len(q.Fields)], fields...)
+	return q
+}
+
+// RemoveField removes every occurrence of the named field from the field list,
+// along with any expressions aliased to that name. An error wrapping
+// ErrSelectStar is returned if that would leave the query without any fields,
+// since it would then select `*`.
+func (q SelectQuery) RemoveField(name string) (SelectQuery, error) {
+	fields := make([]string, 0, len(q.Fields))
+	for _, field := range q.Fields {
+		if field != name {
+			fields = append(fields, field)
+		}
+	}
+	q.Fields = fields
+
+	var exprs []Query
+	for _, expr := range q.Exprs {
+		if a, ok := expr.(AliasClause); ok && a.Alias == name {
+			continue
+		}
+		exprs = append(exprs, expr)
+	}
+	q.Exprs = exprs
+	if len(q.Fields) == 0 && len(q.Exprs) == 0 {
+		return SelectQuery{}, fmt.Errorf("qb: remove field %s: %w", name, ErrSelectStar)
+	}
+	return q, nil
+}
+
+// Expr adds one or more expressions to the field list of the query. This is
+// typically used with As to select scalar subqueries e.g.
+// `(SELECT COUNT(*) FROM photos WHERE ...) AS photo_count`.
+func (q SelectQuery) Expr(exprs ...Query) SelectQuery {
+	q.Exprs = append(q.Exprs[:len(q.Exprs):len(q.Exprs)], exprs...)
+	return q
 }
 
 // Where adds an additional WHERE clause condition to the query that will be
-// evaluated and injected into the final query string.
+// evaluated and injected into the final query string. Calling Where again
+// combines the conditions with AND.
 func (q SelectQuery) Where(wq Query) SelectQuery {
-	q.WhereClause = wq
-	q.Vals = append(q.Vals, wq.Values()...)
+	q.WhereClause, q.Vals = andWhere(q.WhereClause, q.Vals, wq)
+	return q
+}
+
+// OrWhere is like Where, but combines the condition with any existing WHERE
+// clause using OR.
+func (q SelectQuery) OrWhere(wq Query) SelectQuery {
+	q.WhereClause, q.Vals = orWhere(q.WhereClause, q.Vals, wq)
 	return q
 }
 
@@ -326,6 +988,103 @@ type JoinQuery struct {
 	Query1   SelectQuery
 	Query2   SelectQuery
 	OnClause Query
+
+	// JoinType is the type of join e.g. INNER or LEFT. If it is empty, the tables
+	// are joined implicitly with a comma and the ON clause becomes part of the
+	// WHERE clause.
+	JoinType string
+
+	// Joins are any further tables joined after the second, in order.
+	Joins []JoinClause
+
+	// WhereClause filters the joined rows. Unlike the WHERE clauses of the
+	// joined queries, it is never moved into an ON clause, so it applies to
+	// the result whatever the type of join.
+	WhereClause Query
+	Vals        []interface{}
+}
+
+// JoinClause is a table joined to the result of a JoinQuery after the first
+// two. Only the WHERE clause of the joined query is moved into the ON clause
+// for LEFT and FULL joins; the conditions of the tables joined before it are
+// left where they are.
+type JoinClause struct {
+	Query    SelectQuery
+	JoinType string
+	OnClause Query
+}
+
+// nullable reports whether the joined table may be missing from a result row.
+func (j JoinClause) nullable() bool {
+	return j.JoinType == "LEFT" || j.JoinType == "FULL"
+}
+
+// Join returns an inner join of q with table, selecting fields from it. Call
+// On on the result to set the join condition, and Join again to join more
+// tables, e.g. `qb.Select("a").Join("b").On("a.id", "b.a_id").Join("c").On(...)`.
+func (q SelectQuery) Join(table string, fields ...string) JoinQuery {
+	return InnerJoin(q, Select(table, fields...))
+}
+
+// Join adds an inner join of table to the query, selecting fields from it. The
+// condition is set by the next call to On.
+func (q JoinQuery) Join(table string, fields ...string) JoinQuery {
+	return q.JoinWith("INNER", Select(table, fields...))
+}
+
+// JoinWith adds a join of the given type, e.g. LEFT, with sq to the query. The
+// condition is set by the next call to On. If the first two tables are joined
+// implicitly, they are joined with INNER JOIN instead so that the conditions
+// of later joins can refer to either of them.
+func (q JoinQuery) JoinWith(joinType string, sq SelectQuery) JoinQuery {
+	q.Joins = append(q.Joins[:len(q.Joins):len(q.Joins)], JoinClause{Query: sq, JoinType: joinType})
+	return q
+}
+
+// InnerJoin returns a query that resolves to the general form `SELECT fields
+// FROM table1 INNER JOIN table2 ON field1 = field2 [WHERE expr]`.
+func InnerJoin(sq1, sq2 SelectQuery) JoinQuery {
+	return JoinQuery{
+		Query1:   sq1,
+		Query2:   sq2,
+		JoinType: "INNER",
+	}
+}
+
+// LeftJoin returns a query that resolves to the general form `SELECT fields
+// FROM table1 LEFT JOIN table2 ON field1 = field2 [WHERE expr]`. Rows of the
+// first query are returned even if nothing in the second matches them. The
+// WHERE clause of the second query is added to the ON clause, so that it
+// restricts which rows are joined rather than removing the unmatched ones.
+func LeftJoin(sq1, sq2 SelectQuery) JoinQuery {
+	return JoinQuery{
+		Query1:   sq1,
+		Query2:   sq2,
+		JoinType: "LEFT",
+	}
+}
+
+// RightJoin is the mirror image of LeftJoin: rows of the second query are
+// always returned and the WHERE clause of the first query is added to the ON
+// clause.
+func RightJoin(sq1, sq2 SelectQuery) JoinQuery {
+	return JoinQuery{
+		Query1:   sq1,
+		Query2:   sq2,
+		JoinType: "RIGHT",
+	}
+}
+
+// FullJoin returns a query that resolves to the general form `SELECT fields
+// FROM table1 FULL JOIN table2 ON field1 = field2`, returning the rows of both
+// queries whether or not they match. Since either side may be missing, the
+// WHERE clauses of both queries are added to the ON clause.
+func FullJoin(sq1, sq2 SelectQuery) JoinQuery {
+	return JoinQuery{
+		Query1:   sq1,
+		Query2:   sq2,
+		JoinType: "FULL",
+	}
 }
 
 // Build returns a query string of the general form `SELECT fields FROM table1,
@@ -334,15 +1093,53 @@ type JoinQuery struct {
 // columns returned are automatically prepended with the related table name to
 // prevent accidental collisions.
 func (q JoinQuery) Build() string {
+	q = q.scoped()
 	fields := make([]string, 0)
-	for _, field := range q.Query1.Fields {
-		fields = append(fields, q.Query1.Table+"."+field)
+	queries := q.queries()
+	for _, sq := range queries {
+		if len(sq.Fields) == 0 && len(sq.Exprs) == 0 {
+			fields = append(fields, sq.Table+".*")
+		}
+		for _, field := range sq.Fields {
+			fields = append(fields, sq.Table+"."+field)
+		}
+	}
+	for _, sq := range queries {
+		for _, expr := range sq.Exprs {
+			fields = append(fields, expr.Build())
+		}
 	}
-	for _, field := range q.Query2.Fields {
-		fields = append(fields, q.Query2.Table+"."+field)
+
+	if joinType := q.joinType(); joinType != "" {
+		on, where := q.conditions()
+		stmt := fmt.Sprintf("SELECT %s FROM %s %s JOIN %s ON %s", strings.Join(fields, ", "), q.Query1.from(), joinType, q.Query2.from(), q.OnClause.Build())
+		for _, sq := range on {
+			stmt += fmt.Sprintf(" AND (%s)", sq.WhereClause.Build())
+		}
+		for _, j := range q.Joins {
+			stmt += fmt.Sprintf(" %s JOIN %s ON %s", j.JoinType, j.Query.from(), j.OnClause.Build())
+			if j.Query.WhereClause != nil && j.nullable() {
+				stmt += fmt.Sprintf(" AND (%s)", j.Query.WhereClause.Build())
+			}
+		}
+		for i, sq := range where {
+			if i == 0 {
+				stmt += fmt.Sprintf(" WHERE (%s)", sq.WhereClause.Build())
+			} else {
+				stmt += fmt.Sprintf(" AND (%s)", sq.WhereClause.Build())
+			}
+		}
+		if q.WhereClause != nil {
+			if len(where) == 0 {
+				stmt += fmt.Sprintf(" WHERE (%s)", q.WhereClause.Build())
+			} else {
+				stmt += fmt.Sprintf(" AND (%s)", q.WhereClause.Build())
+			}
+		}
+		return stmt
 	}
 
-	stmt := fmt.Sprintf("SELECT %s FROM %s, %s", strings.Join(fields, ", "), q.Query1.Table, q.Query2.Table)
+	stmt := fmt.Sprintf("SELECT %s FROM %s, %s", strings.Join(fields, ", "), q.Query1.from(), q.Query2.from())
 	stmt += fmt.Sprintf(" WHERE %s", q.OnClause.Build())
 	// This feels pretty hacky, but somehow works
 	if q1Where := q.Query1.WhereClause; q1Where != nil {
@@ -351,29 +1148,146 @@ func (q JoinQuery) Build() string {
 	if q2Where := q.Query2.WhereClause; q2Where != nil {
 		stmt += fmt.Sprintf(" AND (%s)", q2Where.Build())
 	}
+	if q.WhereClause != nil {
+		stmt += fmt.Sprintf(" AND (%s)", q.WhereClause.Build())
+	}
 	return stmt
 }
 
 // On sets the fields for the WHERE query that is required to join the two
-// tables.
+// tables. If further tables have been joined with Join or JoinWith, it sets
+// the condition of the last one instead.
 func (q JoinQuery) On(field1, field2 string) JoinQuery {
-	q.OnClause = On{
+	on := On{
 		Field1: field1,
 		Field2: field2,
 	}
+	if n := len(q.Joins); n > 0 {
+		q.Joins = append(q.Joins[:n-1:n-1], q.Joins[n-1])
+		q.Joins[n-1].OnClause = on
+		return q
+	}
+	q.OnClause = on
 	return q
 }
 
 func (q JoinQuery) String() string {
-	b, err := json.MarshalIndent(q, "", "    ")
-	if err != nil {
+	return Dump(q)
+}
+
+// Values returns the aggregate of the values from all of the Queries. Values
+// for the field list expressions of every query come before any WHERE values.
+func (q JoinQuery) Values() []interface{} {
+	q = q.scoped()
+	var vals []interface{}
+	for _, sq := range q.queries() {
+		vals = append(vals, sq.exprValues()...)
+	}
+	vals = append(vals, q.Query1.fromValues()...)
+	vals = append(vals, q.Query2.fromValues()...)
+	if q.joinType() != "" {
+		vals = append(vals, q.OnClause.Values()...)
+		on, where := q.conditions()
+		for _, sq := range on {
+			vals = append(vals, sq.Vals...)
+		}
+		for _, j := range q.Joins {
+			vals = append(vals, j.Query.fromValues()...)
+			vals = append(vals, j.OnClause.Values()...)
+			if j.nullable() {
+				vals = append(vals, j.Query.Vals...)
+			}
+		}
+		for _, sq := range where {
+			vals = append(vals, sq.Vals...)
+		}
+		return append(vals, q.Vals...)
+	}
+	vals = append(vals, q.Query1.Vals...)
+	vals = append(vals, q.Query2.Vals...)
+	return append(vals, q.Vals...)
+}
+
+// scoped returns a copy of q with the default scopes of every query applied.
+func (q JoinQuery) scoped() JoinQuery {
+	q.Query1, q.Query2 = q.Query1.scoped(), q.Query2.scoped()
+	joins := make([]JoinClause, len(q.Joins))
+	for i, j := range q.Joins {
+		j.Query = j.Query.scoped()
+		joins[i] = j
+	}
+	q.Joins = joins
+	return q
+}
+
+// queries returns every query in the join, in order.
+func (q JoinQuery) queries() []SelectQuery {
+	queries := []SelectQuery{q.Query1, q.Query2}
+	for _, j := range q.Joins {
+		queries = append(queries, j.Query)
+	}
+	return queries
+}
+
+// joinType returns the type of the first join. Implicit joins are made
+// explicit when further tables are joined.
+func (q JoinQuery) joinType() string {
+	if q.JoinType == "" && len(q.Joins) > 0 {
+		return "INNER"
+	}
+	return q.JoinType
+}
+
+// conditions splits the queries with a WHERE clause into those whose clause
+// belongs in the ON clause of the first join and those whose clause belongs
+// in the WHERE clause, depending on which side of the join may be missing.
+// Further joined tables whose clause belongs in the WHERE clause come last.
+func (q JoinQuery) conditions() (on, where []SelectQuery) {
+	joinType := q.joinType()
+	for i, sq := range []SelectQuery{q.Query1, q.Query2} {
+		if sq.WhereClause == nil {
+			continue
+		}
+		nullable := joinType == "FULL" || (i == 0 && joinType == "RIGHT") || (i == 1 && joinType == "LEFT")
+		if nullable {
+			on = append(on, sq)
+		} else {
+			where = append(where, sq)
+		}
+	}
+	for _, j := range q.Joins {
+		if j.Query.WhereClause != nil && !j.nullable() {
+			where = append(where, j.Query)
+		}
+	}
+	return on, where
+}
+
+// ToSql is like SelectQuery.ToSql.
+func (q JoinQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(q)
+}
+
+// BuildFor is like SelectQuery.BuildFor.
+func (q JoinQuery) BuildFor(d Dialect) string {
+	return BuildFor(q, d)
+}
+
+// Kind returns KindSelect.
+func (q JoinQuery) Kind() Kind {
+	return KindSelect
+}
+
+// placeholders returns a comma-separated list of n placeholders.
+func placeholders(n int) string {
+	if n == 0 {
 		return ""
 	}
-	return string(b)
+	return strings.Repeat("?, ", n-1) + "?"
 }
 
-// Values returns the aggregate of the values from the two Queries.
-func (q JoinQuery) Values() []interface{} {
-	vals := q.Query1.Values()
-	return append(vals, q.Query2.Values()...)
+// quoteLiteral returns s as a single-quoted SQL string literal. It is only used
+// where the database doesn't accept bound parameters.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
 }
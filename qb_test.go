@@ -1,6 +1,7 @@
 package qb_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -37,6 +38,18 @@ func TestDeleteQuery(t *testing.T) {
 				vals:  []interface{}{12345},
 			},
 		},
+		testcase{
+			name: "ordered query with limit",
+			query: qb.
+				Delete("events").
+				Where(qb.Less("created_at", "2024-01-01")).
+				Sort(qb.OrderByClause{{Field: "created_at", Dir: qb.Asc}}).
+				Limit(1000),
+			want: output{
+				query: `DELETE FROM events WHERE created_at < ? ORDER BY created_at ASC LIMIT ?`,
+				vals:  []interface{}{"2024-01-01", 1000},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -161,6 +174,55 @@ func TestInsertQuery(t *testing.T) {
 				query: `SELECT id FROM vehicles WHERE make IN (?)`,
 			},
 		},
+		testcase{
+			name: "simple query with in values",
+			query: qb.
+				Select("vehicles", "id").
+				Where(qb.And(qb.In("make", "Honda", "Toyota"), qb.Less("cost", 10))),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE (make IN (?, ?) AND cost < ?)`,
+				vals:  []interface{}{"Honda", "Toyota", 10},
+			},
+		},
+		testcase{
+			name: "simple query with not equal and like",
+			query: qb.
+				Select("vehicles", "id").
+				Where(qb.And(qb.NotEqual("make", "Honda"), qb.Or(qb.Like("model", "C%"), qb.NotLike("trim", "%sport%")))),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE (make != ? AND (model LIKE ? OR trim NOT LIKE ?))`,
+				vals:  []interface{}{"Honda", "C%", "%sport%"},
+			},
+		},
+		testcase{
+			name: "simple query with null checks",
+			query: qb.
+				Select("vehicles", "id").
+				Where(qb.And(qb.IsNull("sold_at"), qb.IsNotNull("vin"))),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE (sold_at IS NULL AND vin IS NOT NULL)`,
+			},
+		},
+		testcase{
+			name: "simple query with between",
+			query: qb.
+				Select("vehicles", "id").
+				Where(qb.And(qb.Between("year", 2010, 2015), qb.NotBetween("cost", qb.Col("floor_price"), 10000))),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE (year BETWEEN ? AND ? AND cost NOT BETWEEN floor_price AND ?)`,
+				vals:  []interface{}{2010, 2015, 10000},
+			},
+		},
+		testcase{
+			name: "simple query with not",
+			query: qb.
+				Select("vehicles", "id").
+				Where(qb.Not(qb.Or(qb.Equal("make", "Honda"), qb.Less("cost", 10)))),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE NOT ((make = ? OR cost < ?))`,
+				vals:  []interface{}{"Honda", 10},
+			},
+		},
 		testcase{
 			name: "join query",
 			query: qb.Join(
@@ -207,6 +269,103 @@ func TestInsertQuery(t *testing.T) {
 				vals:  []interface{}{"Honda"},
 			},
 		},
+		testcase{
+			name: "scalar sub query in field list",
+			query: qb.
+				Select("vehicles", "id").
+				Expr(qb.As(
+					qb.
+						Select("photos", "COUNT(*)").
+						Where(qb.Equal("photos.vehicle_id", qb.Col("vehicles.id"))),
+					"photo_count",
+				)).
+				Where(qb.Equal("make", "Honda")),
+			want: output{
+				query: `SELECT id, (SELECT COUNT(*) FROM photos WHERE photos.vehicle_id = vehicles.id) AS photo_count FROM vehicles WHERE make = ?`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name: "scalar sub query values precede where values",
+			query: qb.
+				Select("vehicles", "id").
+				Where(qb.Equal("make", "Honda")).
+				Expr(qb.As(
+					qb.
+						Select("photos", "COUNT(*)").
+						Where(qb.And(
+							qb.Equal("photos.vehicle_id", qb.Col("vehicles.id")),
+							qb.Equal("photos.public", true),
+						)),
+					"photo_count",
+				)),
+			want: output{
+				query: `SELECT id, (SELECT COUNT(*) FROM photos WHERE (photos.vehicle_id = vehicles.id AND photos.public = ?)) AS photo_count FROM vehicles WHERE make = ?`,
+				vals:  []interface{}{true, "Honda"},
+			},
+		},
+		testcase{
+			name: "added fields",
+			query: qb.
+				Select("users", "email").
+				AddFields("id", "name"),
+			want: output{
+				query: `SELECT email, id, name FROM users`,
+			},
+		},
+		testcase{
+			name: "partition",
+			query: qb.
+				Select("orders", "id").
+				Partition("p2023", "p2024").
+				Where(qb.Equal("status", "open")),
+			want: output{
+				query: `SELECT id FROM orders PARTITION (p2023, p2024) WHERE status = ?`,
+				vals:  []interface{}{"open"},
+			},
+		},
+		testcase{
+			name: "table sample",
+			query: qb.
+				Select("events", "id").
+				Expr(qb.As(qb.Equal("kind", "click"), "is_click")).
+				TableSample("BERNOULLI", 1).
+				Where(qb.Greater("created_at", "2024-01-01")),
+			want: output{
+				query: `SELECT id, (kind = ?) AS is_click FROM events TABLESAMPLE BERNOULLI (?) WHERE created_at > ?`,
+				vals:  []interface{}{"click", 1.0, "2024-01-01"},
+			},
+		},
+		testcase{
+			name: "hints",
+			query: qb.
+				Select("vehicles", "id").
+				Hint("INDEX(vehicles idx_make)").
+				Hint("MAX_EXECUTION_TIME(1000)").
+				Where(qb.Equal("make", "Honda")),
+			want: output{
+				query: `SELECT /*+ INDEX(vehicles idx_make) MAX_EXECUTION_TIME(1000) */ id FROM vehicles WHERE make = ?`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name: "hint cannot close comment",
+			query: qb.
+				Select("vehicles", "id").
+				Hint("x */ DROP TABLE vehicles; /*"),
+			want: output{
+				query: `SELECT /*+ x  DROP TABLE vehicles  */ id FROM vehicles`,
+			},
+		},
+		testcase{
+			name: "aliased column",
+			query: qb.
+				Select("vehicles").
+				Expr(qb.As(qb.Col("id"), "vehicle_id")),
+			want: output{
+				query: `SELECT id AS vehicle_id FROM vehicles`,
+			},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, test(tc))
@@ -232,3 +391,137 @@ func test(tc testcase) func(t *testing.T) {
 		}
 	}
 }
+
+func TestRemoveField(t *testing.T) {
+	q, err := qb.Select("users", "id", "ssn", "email").
+		Expr(qb.As(qb.Col("users.ssn"), "tax_id")).
+		RemoveField("ssn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err = q.RemoveField("tax_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `SELECT id, email FROM users`
+	if got := q.Build(); got != want {
+		t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", want, got)
+	}
+
+	if _, err := qb.Select("users", "ssn").RemoveField("ssn"); !errors.Is(err, qb.ErrSelectStar) {
+		t.Errorf("wanted ErrSelectStar removing the last field, got %v", err)
+	}
+}
+
+func TestAddFieldsDoesNotModifyOriginal(t *testing.T) {
+	base := qb.Select("users", "id", "email")
+	removed, err := base.RemoveField("email")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = removed.AddFields("name")
+	_ = base.AddFields("ssn")
+
+	want := `SELECT id, email FROM users`
+	if got := base.Build(); got != want {
+		t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", want, got)
+	}
+}
+
+func TestSelectLimit(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name: "limit and offset",
+			query: qb.Select("vehicles", "id").
+				Where(qb.Equal("make", "Honda")).
+				OrderBy("id", qb.Asc).
+				Limit(20).
+				Offset(40),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE make = ? ORDER BY id ASC LIMIT ? OFFSET ?`,
+				vals:  []interface{}{"Honda", 20, 40},
+			},
+		},
+		testcase{
+			name:  "limit only",
+			query: qb.Select("vehicles", "id").Limit(1),
+			want: output{
+				query: `SELECT id FROM vehicles LIMIT ?`,
+				vals:  []interface{}{1},
+			},
+		},
+		testcase{
+			name:  "limit removed",
+			query: qb.Select("vehicles", "id").Limit(10).Limit(0),
+			want: output{
+				query: `SELECT id FROM vehicles`,
+			},
+		},
+		testcase{
+			name: "limited subquery",
+			query: qb.Select("photos", "url").
+				Where(qb.Equal("vehicle_id", qb.Select("vehicles", "id").OrderBy("cost", qb.Desc).Limit(1))).
+				Limit(5),
+			want: output{
+				query: `SELECT url FROM photos WHERE vehicle_id = (SELECT id FROM vehicles ORDER BY cost DESC LIMIT ?) LIMIT ?`,
+				vals:  []interface{}{1, 5},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
+
+func TestWhereAccumulates(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name: "select",
+			query: qb.Select("vehicles", "id").
+				Where(qb.Equal("make", "Honda")).
+				Where(qb.Less("cost", 10000)),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE (make = ? AND cost < ?)`,
+				vals:  []interface{}{"Honda", 10000},
+			},
+		},
+		testcase{
+			name: "select or",
+			query: qb.Select("vehicles", "id").
+				Where(qb.Equal("make", "Honda")).
+				OrWhere(qb.Equal("make", "Acura")).
+				Where(qb.Less("cost", 10000)),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE ((make = ? OR make = ?) AND cost < ?)`,
+				vals:  []interface{}{"Honda", "Acura", 10000},
+			},
+		},
+		testcase{
+			name:  "or without where",
+			query: qb.Select("vehicles", "id").OrWhere(qb.Equal("make", "Honda")),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE make = ?`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name:  "delete",
+			query: qb.Delete("vehicles").Where(qb.Equal("sold", true)).Where(qb.Less("year", 2000)),
+			want: output{
+				query: `DELETE FROM vehicles WHERE (sold = ? AND year < ?)`,
+				vals:  []interface{}{true, 2000},
+			},
+		},
+		testcase{
+			name:  "update",
+			query: qb.Update("vehicles").Set("cost", 0).Where(qb.Equal("sold", true)).OrWhere(qb.Less("year", 2000)),
+			want: output{
+				query: `UPDATE vehicles SET cost = ? WHERE (sold = ? OR year < ?)`,
+				vals:  []interface{}{0, true, 2000},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
@@ -0,0 +1,66 @@
+package qb
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// Raw returns a query made from hand-written SQL. The SQL may contain `?`
+// placeholders, one for each of the given values.
+func Raw(sql string, vals ...interface{}) RawQuery {
+	return RawQuery{
+		SQL:  sql,
+		Vals: vals,
+	}
+}
+
+// FromFile returns a query made from a hand-written .sql file, typically from
+// an embed.FS. Surrounding whitespace and a trailing semicolon are removed so
+// the query can be used as a subquery in a larger tree.
+func FromFile(fsys fs.FS, path string, vals ...interface{}) (RawQuery, error) {
+	b, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return RawQuery{}, fmt.Errorf("qb: read query: %w", err)
+	}
+	sql := strings.TrimSpace(string(b))
+	sql = strings.TrimSpace(strings.TrimSuffix(sql, ";"))
+	return Raw(sql, vals...), nil
+}
+
+// RawQuery represents a hand-written SQL query and its values. The SQL is used
+// verbatim, so it is up to the author to make sure the number of placeholders
+// matches the number of values.
+type RawQuery struct {
+	SQL  string
+	Vals []interface{}
+}
+
+// Build returns the SQL as-is.
+func (q RawQuery) Build() string {
+	return q.SQL
+}
+
+func (q RawQuery) String() string {
+	return q.Build()
+}
+
+// Values returns the values given when the query was created.
+func (q RawQuery) Values() []interface{} {
+	return q.Vals
+}
+
+// ToSql is like SelectQuery.ToSql.
+func (q RawQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(q)
+}
+
+// BuildFor is like SelectQuery.BuildFor.
+func (q RawQuery) BuildFor(d Dialect) string {
+	return BuildFor(q, d)
+}
+
+// Kind returns KindRaw, since the statement isn't parsed.
+func (q RawQuery) Kind() Kind {
+	return KindRaw
+}
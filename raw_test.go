@@ -0,0 +1,46 @@
+package qb_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestRawQuery(t *testing.T) {
+	honda, err := qb.FromFile(os.DirFS("testdata"), "honda_ids.sql", "Honda")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:  "raw query",
+			query: qb.Raw("SELECT id FROM vehicles WHERE cost BETWEEN ? AND ?", 1, 10),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE cost BETWEEN ? AND ?`,
+				vals:  []interface{}{1, 10},
+			},
+		},
+		testcase{
+			name: "file as sub query",
+			query: qb.
+				Select("photos", "url").
+				Where(qb.And(
+					qb.Equal("public", true),
+					qb.Equal("vehicle_id", honda),
+				)),
+			want: output{
+				query: "SELECT url FROM photos WHERE (public = ? AND vehicle_id = (SELECT id\nFROM vehicles\nWHERE make = ?))",
+				vals:  []interface{}{true, "Honda"},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+
+	if _, err := qb.FromFile(os.DirFS("testdata"), "missing.sql"); err == nil {
+		t.Error("expected an error reading a missing file")
+	}
+}
@@ -0,0 +1,198 @@
+package qb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnmaskedColumn is returned by RedactionPolicy.Apply if a query reads a
+// masked column in a way the policy can't rewrite.
+var ErrUnmaskedColumn = errors.New("query reads a masked column")
+
+// Masker returns the expression that should be selected in place of the given
+// column. The column is always qualified with its table name.
+type Masker func(column string) Query
+
+// MaskConstant replaces a column with the constant string `'***'`.
+func MaskConstant(column string) Query {
+	return MaskExpr{Column: column}
+}
+
+// MaskHash replaces a column with the MD5 hash of its text representation. The
+// hash is stable, so masked values can still be grouped and compared.
+func MaskHash(column string) Query {
+	return MaskExpr{Column: column, Hash: true}
+}
+
+// MaskExpr represents the expression selected in place of a masked column.
+type MaskExpr struct {
+	Column string
+	Hash   bool
+}
+
+// Build returns the constant `'***'`, or an expression of the form
+// `md5(CAST(column AS TEXT))` if the column is hashed.
+func (e MaskExpr) Build() string {
+	if e.Hash {
+		return fmt.Sprintf("md5(CAST(%s AS TEXT))", e.Column)
+	}
+	return "'***'"
+}
+
+func (e MaskExpr) String() string {
+	return e.Build()
+}
+
+// Values always returns nil for MaskExpr.
+func (e MaskExpr) Values() []interface{} {
+	return nil
+}
+
+func (e MaskExpr) scalar() {}
+
+// NewRedactionPolicy returns an empty redaction policy.
+func NewRedactionPolicy() RedactionPolicy {
+	return RedactionPolicy{
+		Masks: make(map[string]Masker),
+	}
+}
+
+// RedactionPolicy describes a set of sensitive columns and how each of them
+// should be masked. A single policy is intended to be defined once and applied
+// to every query that runs under a restricted role. The policy has no notion of
+// roles itself; callers decide which queries it is applied to, usually based on
+// the role of the current user.
+type RedactionPolicy struct {
+	Masks map[string]Masker
+}
+
+// Mask adds columns to the policy that will be replaced with `'***'`.
+func (p RedactionPolicy) Mask(columns ...string) RedactionPolicy {
+	return p.With(MaskConstant, columns...)
+}
+
+// Hash adds columns to the policy that will be replaced with their MD5 hash.
+func (p RedactionPolicy) Hash(columns ...string) RedactionPolicy {
+	return p.With(MaskHash, columns...)
+}
+
+// With adds columns to the policy that will be masked using a custom Masker.
+// Columns may be given either bare (`ssn`), in which case they match in any
+// table, or qualified (`users.ssn`).
+func (p RedactionPolicy) With(m Masker, columns ...string) RedactionPolicy {
+	masks := make(map[string]Masker, len(p.Masks)+len(columns))
+	for column, mask := range p.Masks {
+		masks[column] = mask
+	}
+	for _, column := range columns {
+		masks[column] = m
+	}
+	p.Masks = masks
+	return p
+}
+
+// Apply rewrites every masked column in the field list of the query to the
+// form `mask AS column`. Masked columns are moved to the end of the field list.
+// An error wrapping ErrUnmaskedColumn is returned if the query selects `*` from
+// a table with masked columns, since the columns involved aren't known until
+// the query is executed, or if an expression refers to a masked column.
+func (p RedactionPolicy) Apply(q SelectQuery) (SelectQuery, error) {
+	if len(q.Fields) == 0 && len(q.Exprs) == 0 && p.masksTable(q.Table) {
+		return SelectQuery{}, fmt.Errorf("qb: redact %s.*: %w", q.Table, ErrUnmaskedColumn)
+	}
+	for _, expr := range q.Exprs {
+		if a, ok := expr.(AliasClause); ok {
+			expr = a.Query
+		}
+		if _, ok := expr.(MaskExpr); ok {
+			continue
+		}
+		if column, ok := p.references(q.Table, expr.Build()); ok {
+			return SelectQuery{}, fmt.Errorf("qb: redact %s: %w", column, ErrUnmaskedColumn)
+		}
+	}
+	fields := make([]string, 0, len(q.Fields))
+	exprs := append([]Query{}, q.Exprs...)
+	for _, field := range q.Fields {
+		if field == "*" || strings.HasSuffix(field, ".*") {
+			table := strings.TrimSuffix(strings.TrimSuffix(field, "*"), ".")
+			if table == "" {
+				table = q.Table
+			}
+			if p.masksTable(table) {
+				return SelectQuery{}, fmt.Errorf("qb: redact %s.*: %w", table, ErrUnmaskedColumn)
+			}
+			fields = append(fields, field)
+			continue
+		}
+		m := p.masker(q.Table, field)
+		if m == nil {
+			fields = append(fields, field)
+			continue
+		}
+		column := field
+		if !strings.Contains(column, ".") {
+			column = q.Table + "." + column
+		}
+		exprs = append(exprs, As(m(column), unqualified(field)))
+	}
+	q.Fields = fields
+	q.Exprs = exprs
+	return q, nil
+}
+
+// masksTable reports whether any column of table is masked by the policy.
+func (p RedactionPolicy) masksTable(table string) bool {
+	for column := range p.Masks {
+		if !strings.Contains(column, ".") || strings.HasPrefix(column, table+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// references returns the first identifier in sql that names a masked column.
+// Quoted strings are skipped, since they can't refer to columns.
+func (p RedactionPolicy) references(table, sql string) (string, bool) {
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\'':
+			for i++; i < len(sql) && sql[i] != c; i++ {
+			}
+		case isIdentStart(c):
+			j := i
+			for j < len(sql) && (isIdentStart(sql[j]) || sql[j] == '.' || sql[j] >= '0' && sql[j] <= '9') {
+				j++
+			}
+			if ident := sql[i:j]; p.masker(table, ident) != nil {
+				return ident, true
+			}
+			i = j - 1
+		}
+	}
+	return "", false
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func (p RedactionPolicy) masker(table, field string) Masker {
+	name := unqualified(field)
+	if m, ok := p.Masks[table+"."+name]; ok {
+		return m
+	}
+	if m, ok := p.Masks[field]; ok {
+		return m
+	}
+	return p.Masks[name]
+}
+
+func unqualified(field string) string {
+	if i := strings.LastIndex(field, "."); i >= 0 {
+		return field[i+1:]
+	}
+	return field
+}
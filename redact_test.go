@@ -0,0 +1,83 @@
+package qb_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestRedactionPolicy(t *testing.T) {
+	policy := qb.NewRedactionPolicy().
+		Mask("ssn").
+		Hash("users.email")
+
+	apply := func(q qb.SelectQuery) qb.SelectQuery {
+		t.Helper()
+		q, err := policy.Apply(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return q
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:  "masked and hashed columns",
+			query: apply(qb.Select("users", "id", "ssn", "email")),
+			want: output{
+				query: `SELECT id, '***' AS ssn, md5(CAST(users.email AS TEXT)) AS email FROM users`,
+			},
+		},
+		testcase{
+			name:  "qualified mask does not match other tables",
+			query: apply(qb.Select("accounts", "id", "email")),
+			want: output{
+				query: `SELECT id, email FROM accounts`,
+			},
+		},
+		testcase{
+			name: "join",
+			query: qb.Join(
+				apply(qb.Select("users", "id", "ssn")),
+				qb.Select("dealerships", "name"),
+			).On("users.dealership_id", "dealerships.id"),
+			want: output{
+				query: `SELECT users.id, dealerships.name, '***' AS ssn FROM users, dealerships WHERE users.dealership_id = dealerships.id`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
+
+func TestRedactionPolicyRejectsUnmaskedReads(t *testing.T) {
+	policy := qb.NewRedactionPolicy().
+		Mask("users.ssn").
+		Hash("email")
+
+	queries := map[string]qb.SelectQuery{
+		"select star":          qb.Select("users"),
+		"qualified star":       qb.Select("users", "id", "users.*"),
+		"bare mask on star":    qb.Select("accounts", "*"),
+		"aliased column":       qb.Select("users", "id").Expr(qb.As(qb.Col("ssn"), "x")),
+		"expression":           qb.Select("users", "id").Expr(qb.As(qb.Raw("lower(email)"), "e")),
+		"qualified expression": qb.Select("users", "id").Expr(qb.Col("users.ssn")),
+	}
+	for name, q := range queries {
+		if _, err := policy.Apply(q); !errors.Is(err, qb.ErrUnmaskedColumn) {
+			t.Errorf("%s: expected ErrUnmaskedColumn, got %v", name, err)
+		}
+	}
+
+	allowed := []qb.SelectQuery{
+		qb.Select("accounts", "id").Expr(qb.As(qb.Raw("'ssn'"), "label")),
+		qb.Select("accounts", "id", "ssn"),
+	}
+	for _, q := range allowed {
+		if _, err := policy.Apply(q); err != nil {
+			t.Errorf("%s: unexpected error: %v", q.Build(), err)
+		}
+	}
+}
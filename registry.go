@@ -0,0 +1,278 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var registry = struct {
+	sync.RWMutex
+	tables map[string]TableMeta
+}{
+	tables: make(map[string]TableMeta),
+}
+
+// TableMeta describes the properties of a table that higher-level helpers need
+// in order to build queries against it without being told explicitly.
+type TableMeta struct {
+	// Name is the name of the table as it appears in queries.
+	Name string
+
+	// PrimaryKey is the list of columns making up the primary key. Most tables
+	// will have a single column here, but composite keys are supported.
+	PrimaryKey []string
+
+	// Columns is the list of columns in the table. It is optional, but when it
+	// is present, helpers that load queries from configuration use it to reject
+	// references to columns that don't exist.
+	Columns []string
+
+	// SoftDeleteColumn is the nullable timestamp column that marks a row as
+	// deleted, if the table uses soft deletes.
+	SoftDeleteColumn string
+
+	// TenantColumn is the column that scopes rows to a tenant, if the table is
+	// shared between tenants.
+	TenantColumn string
+
+	// ForeignKeys is the list of columns in this table that reference other
+	// tables.
+	ForeignKeys []ForeignKey
+
+	// DefaultOrder is the list of ORDER BY terms e.g. `created_at DESC` that
+	// should be used when a query doesn't specify its own ordering.
+	DefaultOrder []string
+
+	// DefaultScopes are applied to every SELECT from the table, such as a
+	// filter that only includes published rows. They are applied when the
+	// query is built, before DefaultOrder, unless the query is Unscoped.
+	DefaultScopes []func(SelectQuery) SelectQuery
+
+	// HistoryTable is the table holding previous versions of rows, for tables
+	// whose history is kept by triggers rather than by the database, such as
+	// with the Postgres temporal_tables extension. If it is set, AsOf reads
+	// from the union of the table and its history.
+	HistoryTable string
+
+	// PeriodColumn is the tstzrange column holding the period during which
+	// each version of a row was current, in both the table and its history.
+	// It defaults to `sys_period`.
+	PeriodColumn string
+}
+
+func (m TableMeta) periodColumn() string {
+	if m.PeriodColumn == "" {
+		return "sys_period"
+	}
+	return m.PeriodColumn
+}
+
+// ForeignKey describes a column that references a column in another table.
+type ForeignKey struct {
+	// Column is the referencing column in the owning table.
+	Column string
+
+	// References is the name of the referenced table.
+	References string
+
+	// ReferencedColumn is the referenced column. If it is empty, the single
+	// primary key column of the referenced table is used, and joins through
+	// the foreign key fail if the primary key has more than one column.
+	ReferencedColumn string
+}
+
+// RegisterTable records the metadata for a table, replacing any metadata that
+// was previously registered under the same name. RegisterTable is safe for
+// concurrent use, but is intended to be called during program initialization.
+// It panics if the table name is empty.
+func RegisterTable(meta TableMeta) {
+	if meta.Name == "" {
+		panic("qb: RegisterTable called with an empty table name")
+	}
+	meta.PrimaryKey = append([]string(nil), meta.PrimaryKey...)
+	meta.Columns = append([]string(nil), meta.Columns...)
+	meta.ForeignKeys = append([]ForeignKey(nil), meta.ForeignKeys...)
+	meta.DefaultOrder = append([]string(nil), meta.DefaultOrder...)
+	meta.DefaultScopes = append([]func(SelectQuery) SelectQuery(nil), meta.DefaultScopes...)
+
+	registry.Lock()
+	defer registry.Unlock()
+	registry.tables[meta.Name] = meta
+}
+
+// LookupTable returns the metadata registered for the named table. The boolean
+// result reports whether any metadata was found.
+func LookupTable(name string) (TableMeta, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	meta, ok := registry.tables[name]
+	return meta, ok
+}
+
+// primaryKey returns the primary key columns for the named table, defaulting to
+// `id` for tables that haven't been registered.
+func primaryKey(table string) []string {
+	if meta, ok := LookupTable(table); ok && len(meta.PrimaryKey) > 0 {
+		return meta.PrimaryKey
+	}
+	return []string{"id"}
+}
+
+// ByPK returns a boolean clause matching a single row of the named table by its
+// primary key. The ids must be given in the same order as the primary key
+// columns in the table's registered metadata. Unregistered tables are assumed
+// to have a single primary key column named `id`. An error is returned if the
+// number of ids doesn't match the number of primary key columns.
+func ByPK(table string, ids ...interface{}) (Query, error) {
+	pk := primaryKey(table)
+	if len(ids) != len(pk) {
+		return nil, fmt.Errorf("qb: table %s has %d primary key column(s), got %d id(s)", table, len(pk), len(ids))
+	}
+
+	var q Query = Equal(pk[0], ids[0])
+	for i := 1; i < len(pk); i++ {
+		q = And(q, Equal(pk[i], ids[i]))
+	}
+	return q, nil
+}
+
+// Get returns a query that resolves to the form `SELECT * FROM table WHERE
+// pk = ?`. See ByPK for how the primary key is resolved.
+func Get(table string, ids ...interface{}) (SelectQuery, error) {
+	where, err := ByPK(table, ids...)
+	if err != nil {
+		return SelectQuery{}, err
+	}
+	return Select(table).Where(where), nil
+}
+
+// DeleteByID returns a query that resolves to the form `DELETE FROM table WHERE
+// pk = ?`. See ByPK for how the primary key is resolved.
+func DeleteByID(table string, ids ...interface{}) (DeleteQuery, error) {
+	where, err := ByPK(table, ids...)
+	if err != nil {
+		return DeleteQuery{}, err
+	}
+	return Delete(table).Where(where), nil
+}
+
+// UpdateByID returns a query that resolves to the form `UPDATE table SET ...
+// WHERE pk = ?`, with the columns to change added using Set. See ByPK for how
+// the primary key is resolved.
+func UpdateByID(table string, ids ...interface{}) (UpdateQuery, error) {
+	where, err := ByPK(table, ids...)
+	if err != nil {
+		return UpdateQuery{}, err
+	}
+	return Update(table).Where(where), nil
+}
+
+// Unscoped disables the default scopes and default ordering registered for the
+// table, so the query is built exactly as written.
+func (q SelectQuery) Unscoped() SelectQuery {
+	q.SkipDefaults = true
+	return q
+}
+
+// scoped returns the query with the default scopes and ordering of its table
+// applied.
+func (q SelectQuery) scoped() SelectQuery {
+	if q.SkipDefaults || q.Table == "" {
+		return q
+	}
+	meta, ok := LookupTable(q.Table)
+	if !ok {
+		return q
+	}
+	q.SkipDefaults = true
+	q = q.Apply(meta.DefaultScopes...)
+	if len(q.Ordering) == 0 {
+		for _, term := range meta.DefaultOrder {
+			q.Ordering = append(q.Ordering, parseOrderTerm(term))
+		}
+	}
+	return q
+}
+
+// parseOrderTerm parses an ORDER BY term of the form `field [ASC|DESC]`.
+func parseOrderTerm(term string) Order {
+	fields := strings.Fields(term)
+	if n := len(fields); n > 1 {
+		switch dir := Direction(strings.ToUpper(fields[n-1])); dir {
+		case Asc, Desc:
+			return Order{Field: strings.Join(fields[:n-1], " "), Dir: dir}
+		}
+	}
+	return Order{Field: strings.TrimSpace(term)}
+}
+
+// JoinTo returns a join between the query and the named table, inferring the ON
+// clause from the foreign keys registered for either table. The fields are
+// selected from the joined table. An error is returned if neither table has
+// exactly one foreign key referencing the other.
+func (q SelectQuery) JoinTo(table string, fields ...string) (JoinQuery, error) {
+	on, err := foreignKeyOn(q.Table, table)
+	if err != nil {
+		return JoinQuery{}, err
+	}
+	jq := Join(q, Select(table, fields...))
+	jq.OnClause = on
+	return jq, nil
+}
+
+func foreignKeyOn(from, to string) (On, error) {
+	var ons []On
+	if meta, ok := LookupTable(from); ok {
+		for _, fk := range meta.ForeignKeys {
+			if fk.References == to {
+				column, err := referencedColumn(fk)
+				if err != nil {
+					return On{}, err
+				}
+				ons = append(ons, On{
+					Field1: from + "." + fk.Column,
+					Field2: to + "." + column,
+				})
+			}
+		}
+	}
+	if meta, ok := LookupTable(to); ok {
+		for _, fk := range meta.ForeignKeys {
+			if fk.References == from {
+				column, err := referencedColumn(fk)
+				if err != nil {
+					return On{}, err
+				}
+				ons = append(ons, On{
+					Field1: from + "." + column,
+					Field2: to + "." + fk.Column,
+				})
+			}
+		}
+	}
+
+	switch len(ons) {
+	case 0:
+		return On{}, fmt.Errorf("qb: no foreign key between %s and %s", from, to)
+	case 1:
+		return ons[0], nil
+	default:
+		return On{}, fmt.Errorf("qb: multiple foreign keys between %s and %s", from, to)
+	}
+}
+
+// referencedColumn returns the column referenced by the foreign key, which is
+// the primary key of the referenced table unless it is given explicitly. An
+// error is returned if the primary key has more than one column, since a single
+// column can't reference it.
+func referencedColumn(fk ForeignKey) (string, error) {
+	if fk.ReferencedColumn != "" {
+		return fk.ReferencedColumn, nil
+	}
+	pk := primaryKey(fk.References)
+	if len(pk) != 1 {
+		return "", fmt.Errorf("qb: foreign key %s references %s, which has a composite primary key; set ReferencedColumn", fk.Column, fk.References)
+	}
+	return pk[0], nil
+}
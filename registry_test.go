@@ -0,0 +1,238 @@
+package qb_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestRegisterTable(t *testing.T) {
+	pk := []string{"id"}
+	qb.RegisterTable(qb.TableMeta{
+		Name:             "registry_vehicles",
+		PrimaryKey:       pk,
+		SoftDeleteColumn: "deleted_at",
+		TenantColumn:     "dealership_id",
+		DefaultOrder:     []string{"created_at DESC"},
+	})
+	pk[0] = "changed"
+
+	got, ok := qb.LookupTable("registry_vehicles")
+	if !ok {
+		t.Fatal("expected table to be registered")
+	}
+	want := qb.TableMeta{
+		Name:             "registry_vehicles",
+		PrimaryKey:       []string{"id"},
+		SoftDeleteColumn: "deleted_at",
+		TenantColumn:     "dealership_id",
+		DefaultOrder:     []string{"created_at DESC"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\n\twanted:\n%+v\n\tgot:\n%+v", want, got)
+	}
+
+	if _, ok := qb.LookupTable("registry_missing"); ok {
+		t.Error("expected unregistered table to be missing")
+	}
+}
+
+func TestRegisterTableWithoutName(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected RegisterTable to panic")
+		}
+	}()
+	qb.RegisterTable(qb.TableMeta{})
+}
+
+func TestByPK(t *testing.T) {
+	qb.RegisterTable(qb.TableMeta{
+		Name:       "registry_vehicle_options",
+		PrimaryKey: []string{"vehicle_id", "option_id"},
+	})
+
+	get, err := qb.Get("registry_dealerships", 12345)
+	if err != nil {
+		t.Fatal(err)
+	}
+	getComposite, err := qb.Get("registry_vehicle_options", 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	del, err := qb.DeleteByID("registry_dealerships", 12345)
+	if err != nil {
+		t.Fatal(err)
+	}
+	update, err := qb.UpdateByID("registry_vehicle_options", 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:  "get unregistered table",
+			query: get,
+			want: output{
+				query: `SELECT * FROM registry_dealerships WHERE id = ?`,
+				vals:  []interface{}{12345},
+			},
+		},
+		testcase{
+			name:  "get composite key",
+			query: getComposite,
+			want: output{
+				query: `SELECT * FROM registry_vehicle_options WHERE (vehicle_id = ? AND option_id = ?)`,
+				vals:  []interface{}{1, 2},
+			},
+		},
+		testcase{
+			name:  "delete by id",
+			query: del,
+			want: output{
+				query: `DELETE FROM registry_dealerships WHERE id = ?`,
+				vals:  []interface{}{12345},
+			},
+		},
+		testcase{
+			name:  "update by id",
+			query: update.Set("price", 100),
+			want: output{
+				query: `UPDATE registry_vehicle_options SET price = ? WHERE (vehicle_id = ? AND option_id = ?)`,
+				vals:  []interface{}{100, 1, 2},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
+
+func TestByPKWithWrongNumberOfIDs(t *testing.T) {
+	if _, err := qb.ByPK("registry_dealerships", 1, 2); err == nil {
+		t.Error("expected an error from ByPK")
+	}
+	if _, err := qb.Get("registry_dealerships"); err == nil {
+		t.Error("expected an error from Get")
+	}
+	if _, err := qb.DeleteByID("registry_dealerships", 1, 2); err == nil {
+		t.Error("expected an error from DeleteByID")
+	}
+	if _, err := qb.UpdateByID("registry_dealerships", 1, 2); err == nil {
+		t.Error("expected an error from UpdateByID")
+	}
+}
+
+func TestJoinTo(t *testing.T) {
+	qb.RegisterTable(qb.TableMeta{
+		Name: "registry_employees",
+		ForeignKeys: []qb.ForeignKey{
+			{Column: "dealership_id", References: "registry_stores"},
+		},
+	})
+	qb.RegisterTable(qb.TableMeta{
+		Name:       "registry_stores",
+		PrimaryKey: []string{"store_id"},
+	})
+
+	forward, err := qb.Select("registry_employees", "id").JoinTo("registry_stores", "name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reverse, err := qb.Select("registry_stores").JoinTo("registry_employees", "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:  "foreign key on source table",
+			query: forward,
+			want: output{
+				query: `SELECT registry_employees.id, registry_stores.name FROM registry_employees, registry_stores WHERE registry_employees.dealership_id = registry_stores.store_id`,
+			},
+		},
+		testcase{
+			name:  "foreign key on joined table",
+			query: reverse,
+			want: output{
+				query: `SELECT registry_stores.*, registry_employees.id FROM registry_stores, registry_employees WHERE registry_stores.store_id = registry_employees.dealership_id`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+
+	if _, err := qb.Select("registry_employees").JoinTo("registry_missing"); err == nil {
+		t.Error("expected an error joining tables without a foreign key")
+	}
+
+	qb.RegisterTable(qb.TableMeta{
+		Name:       "registry_options",
+		PrimaryKey: []string{"vehicle_id", "option_id"},
+	})
+	qb.RegisterTable(qb.TableMeta{
+		Name: "registry_quotes",
+		ForeignKeys: []qb.ForeignKey{
+			{Column: "option_id", References: "registry_options"},
+		},
+	})
+	if _, err := qb.Select("registry_quotes").JoinTo("registry_options"); err == nil {
+		t.Error("expected an error joining to a composite primary key")
+	}
+	if _, err := qb.Select("registry_options").JoinTo("registry_quotes"); err == nil {
+		t.Error("expected an error joining from a composite primary key")
+	}
+}
+
+func TestDefaultScopes(t *testing.T) {
+	qb.RegisterTable(qb.TableMeta{
+		Name:         "registry_posts",
+		DefaultOrder: []string{"published_at DESC", "id"},
+		DefaultScopes: []func(qb.SelectQuery) qb.SelectQuery{
+			func(q qb.SelectQuery) qb.SelectQuery {
+				return q.Filter(qb.NewFilter("published", qb.Equal("published", true)))
+			},
+		},
+	})
+
+	testcases := []testcase{
+		testcase{
+			name:  "default scopes",
+			query: qb.Select("registry_posts", "id").Where(qb.Equal("author_id", 7)),
+			want: output{
+				query: `SELECT id FROM registry_posts WHERE (author_id = ? AND published = ?) ORDER BY published_at DESC, id`,
+				vals:  []interface{}{7, true},
+			},
+		},
+		testcase{
+			name:  "explicit order",
+			query: qb.Select("registry_posts", "id").Sort(qb.OrderByClause{{Field: "title", Dir: qb.Asc}}),
+			want: output{
+				query: `SELECT id FROM registry_posts WHERE published = ? ORDER BY title ASC`,
+				vals:  []interface{}{true},
+			},
+		},
+		testcase{
+			name:  "unscoped",
+			query: qb.Select("registry_posts", "id").Where(qb.Equal("author_id", 7)).Unscoped(),
+			want: output{
+				query: `SELECT id FROM registry_posts WHERE author_id = ?`,
+				vals:  []interface{}{7},
+			},
+		},
+		testcase{
+			name:  "join",
+			query: qb.Join(qb.Select("registry_authors", "name"), qb.Select("registry_posts", "title")).On("registry_authors.id", "registry_posts.author_id"),
+			want: output{
+				query: `SELECT registry_authors.name, registry_posts.title FROM registry_authors, registry_posts WHERE registry_authors.id = registry_posts.author_id AND (published = ?)`,
+				vals:  []interface{}{true},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
@@ -0,0 +1,42 @@
+package qb
+
+import "time"
+
+// Retain returns a retention policy that removes rows from the table whose
+// timestamp column is older than maxAge.
+func Retain(table, column string, maxAge time.Duration) RetentionPolicy {
+	return RetentionPolicy{
+		Table:  table,
+		Column: column,
+		MaxAge: maxAge,
+	}
+}
+
+// RetentionPolicy describes how long rows in a table should be kept, based on
+// one of its timestamp columns.
+type RetentionPolicy struct {
+	Table     string
+	Column    string
+	MaxAge    time.Duration
+	BatchSize int
+}
+
+// InBatchesOf limits each purge to at most n rows, oldest first. Running the
+// purge repeatedly until it affects fewer than n rows removes all expired rows
+// without locking them all at once. Batched purges rely on ordered deletes,
+// which are only supported by MySQL.
+func (p RetentionPolicy) InBatchesOf(n int) RetentionPolicy {
+	p.BatchSize = n
+	return p
+}
+
+// Purge returns the DELETE that removes rows that have expired as of now. The
+// cutoff is computed once and bound as a parameter, so the same query can be
+// run repeatedly for every batch of a single purge.
+func (p RetentionPolicy) Purge(now time.Time) DeleteQuery {
+	q := Delete(p.Table).Where(Less(p.Column, now.Add(-p.MaxAge)))
+	if p.BatchSize > 0 {
+		q = q.Sort(OrderByClause{{Field: p.Column, Dir: Asc}}).Limit(p.BatchSize)
+	}
+	return q
+}
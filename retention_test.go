@@ -0,0 +1,36 @@
+package qb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestRetentionPolicy(t *testing.T) {
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	policy := qb.Retain("events", "created_at", 29*24*time.Hour)
+
+	testcases := []testcase{
+		testcase{
+			name:  "purge",
+			query: policy.Purge(now),
+			want: output{
+				query: `DELETE FROM events WHERE created_at < ?`,
+				vals:  []interface{}{cutoff},
+			},
+		},
+		testcase{
+			name:  "batched purge",
+			query: policy.InBatchesOf(500).Purge(now),
+			want: output{
+				query: `DELETE FROM events WHERE created_at < ? ORDER BY created_at ASC LIMIT ?`,
+				vals:  []interface{}{cutoff, 500},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
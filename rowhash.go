@@ -0,0 +1,117 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RowHash returns an expression that resolves to the Postgres form
+// `md5(CAST(table AS TEXT))`, hashing the text representation of a whole row.
+// Comparing row hashes is a cheap way to find rows that differ between two
+// copies of a table, but the result depends on the column order and types of
+// the table, so both sides must have identical definitions. See ColumnsHash
+// for a form that doesn't. When built for another dialect, the columns
+// registered for the table are hashed instead; see HashExpr.Build.
+func RowHash(table string) Query {
+	return HashExpr{Table: table}
+}
+
+// ColumnsHash returns an expression that hashes the given columns in order by
+// concatenating their text representations, using the form
+// `md5(concat_ws('|', COALESCE(CAST(col AS TEXT), '\N'), ...))`. NULLs are
+// replaced with a marker so they hash differently from empty strings. Unlike
+// RowHash, the hash only depends on the listed columns, so it can be used to
+// compare tables whose definitions differ, or to ignore columns such as
+// timestamps that are expected to differ.
+func ColumnsHash(columns ...string) Query {
+	return HashExpr{Columns: columns}
+}
+
+// HashExpr represents the MD5 hash of either a whole row of a table or a list
+// of columns.
+type HashExpr struct {
+	Table   string
+	Columns []string
+
+	dialect Dialect
+}
+
+// Build returns an expression of the form `md5(CAST(table AS TEXT))`, or
+// `md5(concat_ws('|', ...))` if columns are given. Other dialects can't convert
+// a whole row to text, so the columns registered for the table with
+// RegisterTable are hashed instead, and the hash is written using the functions
+// of the dialect: `MD5(CONCAT_WS('|', ...))` on MySQL and `HASHBYTES('MD5',
+// CONCAT_WS('|', ...))` converted to lowercase hex on SQL Server. SQLite has no
+// hash function, so the columns are compared by their concatenated text, of the
+// form `(... || '|' || ...)`.
+func (e HashExpr) Build() string {
+	d := dialectName(e.dialect)
+	columns := e.Columns
+	if len(columns) == 0 {
+		meta, ok := LookupTable(e.Table)
+		if d == "" || d == "postgres" || !ok || len(meta.Columns) == 0 {
+			return fmt.Sprintf("md5(CAST(%s AS TEXT))", e.Table)
+		}
+		for _, column := range meta.Columns {
+			columns = append(columns, e.Table+"."+column)
+		}
+	}
+	// MySQL treats backslashes in strings as escapes, so the NULL marker needs
+	// one more to come out the same.
+	text, null := "TEXT", `'\N'`
+	switch d {
+	case "mysql":
+		text, null = "CHAR", `'\\N'`
+	case "sqlserver":
+		text = "VARCHAR(MAX)"
+	}
+	parts := make([]string, 0, len(columns))
+	for _, column := range columns {
+		parts = append(parts, fmt.Sprintf("COALESCE(CAST(%s AS %s), %s)", column, text, null))
+	}
+	switch d {
+	case "mysql":
+		return fmt.Sprintf("MD5(CONCAT_WS('|', %s))", strings.Join(parts, ", "))
+	case "sqlserver":
+		return fmt.Sprintf("LOWER(CONVERT(VARCHAR(32), HASHBYTES('MD5', CONCAT_WS('|', %s)), 2))", strings.Join(parts, ", "))
+	case "sqlite":
+		return fmt.Sprintf("(%s)", strings.Join(parts, " || '|' || "))
+	}
+	return fmt.Sprintf("md5(concat_ws('|', %s))", strings.Join(parts, ", "))
+}
+
+func (e HashExpr) withDialect(d Dialect) interface{} {
+	e.dialect = d
+	return e
+}
+
+func (e HashExpr) String() string {
+	return e.Build()
+}
+
+// Values always returns nil for HashExpr.
+func (e HashExpr) Values() []interface{} {
+	return nil
+}
+
+func (e HashExpr) scalar() {}
+
+// HashRows returns a query that selects the key columns of every row in a table
+// along with a hash of the given columns, aliased as `row_hash` and ordered by
+// the key. Running the same query against two databases and merging the
+// results by key finds the rows that were added, removed or changed. If no
+// columns are given, the whole row is hashed using RowHash.
+func HashRows(table string, key []string, columns ...string) SelectQuery {
+	hash := RowHash(table)
+	if len(columns) > 0 {
+		hash = ColumnsHash(columns...)
+	}
+	order := make(OrderByClause, 0, len(key))
+	for _, k := range key {
+		order = append(order, Order{Field: k, Dir: Asc})
+	}
+	return Select(table, key...).
+		Expr(As(hash, "row_hash")).
+		Sort(order).
+		Unscoped()
+}
@@ -0,0 +1,37 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestRowHash(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name:  "whole row",
+			query: qb.HashRows("vehicles", []string{"id"}),
+			want: output{
+				query: `SELECT id, md5(CAST(vehicles AS TEXT)) AS row_hash FROM vehicles ORDER BY id ASC`,
+			},
+		},
+		testcase{
+			name:  "columns",
+			query: qb.HashRows("vehicle_options", []string{"vehicle_id", "option_id"}, "price", "notes"),
+			want: output{
+				query: `SELECT vehicle_id, option_id, md5(concat_ws('|', COALESCE(CAST(price AS TEXT), '\N'), COALESCE(CAST(notes AS TEXT), '\N'))) AS row_hash FROM vehicle_options ORDER BY vehicle_id ASC, option_id ASC`,
+			},
+		},
+		testcase{
+			name:  "comparison",
+			query: qb.Select("vehicles", "id").Where(qb.Equal("id", 1)).Expr(qb.As(qb.RowHash("vehicles"), "h")),
+			want: output{
+				query: `SELECT id, md5(CAST(vehicles AS TEXT)) AS h FROM vehicles WHERE id = ?`,
+				vals:  []interface{}{1},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
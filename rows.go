@@ -0,0 +1,43 @@
+package qb
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// RowCountError is returned by ExpectRowsAffected when a statement affected a
+// different number of rows than expected.
+type RowCountError struct {
+	Want int64
+	Got  int64
+}
+
+func (e *RowCountError) Error() string {
+	return fmt.Sprintf("qb: expected %d row(s) to be affected, got %d", e.Want, e.Got)
+}
+
+// ExpectRowsAffected checks the result of executing a statement against the
+// number of rows it should have affected, which is a common invariant for
+// statements that target a single row by ID:
+//
+//	res, err := tx.ExecContext(ctx, q.Build(), q.Values()...)
+//	if err != nil {
+//		return err
+//	}
+//	if err := qb.ExpectRowsAffected(res, 1); err != nil {
+//		tx.Rollback()
+//		return err
+//	}
+//
+// It returns a *RowCountError on a mismatch, or the error from RowsAffected if
+// the driver doesn't support it.
+func ExpectRowsAffected(res sql.Result, n int64) error {
+	got, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if got != n {
+		return &RowCountError{Want: n, Got: got}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package qb_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+type fakeResult struct {
+	rows int64
+	err  error
+}
+
+func (r fakeResult) LastInsertId() (int64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (r fakeResult) RowsAffected() (int64, error) {
+	return r.rows, r.err
+}
+
+func TestExpectRowsAffected(t *testing.T) {
+	if err := qb.ExpectRowsAffected(fakeResult{rows: 1}, 1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := qb.ExpectRowsAffected(fakeResult{rows: 0}, 1)
+	var rcErr *qb.RowCountError
+	if !errors.As(err, &rcErr) || rcErr.Want != 1 || rcErr.Got != 0 {
+		t.Errorf("expected a row count error, got %v", err)
+	}
+
+	unsupported := errors.New("unsupported")
+	if err := qb.ExpectRowsAffected(fakeResult{err: unsupported}, 1); err != unsupported {
+		t.Errorf("expected the driver error, got %v", err)
+	}
+}
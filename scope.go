@@ -0,0 +1,41 @@
+package qb
+
+// Apply passes the query through each scope in order and returns the result.
+// Scopes are plain functions, so reusable refinements such as "only published
+// rows" or "visible to a user" can be defined once and chained onto any query:
+//
+//	func Published(q qb.SelectQuery) qb.SelectQuery {
+//		return q.Filter(qb.NewFilter("published", qb.Equal("published", true)))
+//	}
+//
+//	q := qb.Select("posts").Apply(Published, VisibleTo(user))
+func (q SelectQuery) Apply(scopes ...func(SelectQuery) SelectQuery) SelectQuery {
+	for _, scope := range scopes {
+		q = scope(q)
+	}
+	return q
+}
+
+// Apply passes the query through each scope in order and returns the result.
+func (q DeleteQuery) Apply(scopes ...func(DeleteQuery) DeleteQuery) DeleteQuery {
+	for _, scope := range scopes {
+		q = scope(q)
+	}
+	return q
+}
+
+// Apply passes the query through each scope in order and returns the result.
+func (q UpdateQuery) Apply(scopes ...func(UpdateQuery) UpdateQuery) UpdateQuery {
+	for _, scope := range scopes {
+		q = scope(q)
+	}
+	return q
+}
+
+// Apply passes the query through each scope in order and returns the result.
+func (q JoinQuery) Apply(scopes ...func(JoinQuery) JoinQuery) JoinQuery {
+	for _, scope := range scopes {
+		q = scope(q)
+	}
+	return q
+}
@@ -0,0 +1,66 @@
+package qb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haleyrc/qb"
+)
+
+func recent(since time.Time) func(qb.SelectQuery) qb.SelectQuery {
+	return func(q qb.SelectQuery) qb.SelectQuery {
+		return q.Filter(qb.NewFilter("recent", qb.GreaterEqual("created_at", since))).
+			Sort(qb.OrderByClause{{Field: "created_at", Dir: qb.Desc}})
+	}
+}
+
+func published(q qb.SelectQuery) qb.SelectQuery {
+	return q.Filter(qb.NewFilter("published", qb.Equal("published", true)))
+}
+
+func TestApply(t *testing.T) {
+	since := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	expired := func(q qb.DeleteQuery) qb.DeleteQuery {
+		return q.Filter(qb.NewFilter("expired", qb.Less("expires_at", since)))
+	}
+	admins := func(q qb.JoinQuery) qb.JoinQuery {
+		return q.Filter(qb.NewFilter("admins", qb.Equal("employees.role", "admin")))
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:  "select",
+			query: qb.Select("posts", "id").Apply(published, recent(since)),
+			want: output{
+				query: `SELECT id FROM posts WHERE (published = ? AND created_at >= ?) ORDER BY created_at DESC`,
+				vals:  []interface{}{true, since},
+			},
+		},
+		testcase{
+			name:  "delete",
+			query: qb.Delete("sessions").Apply(expired),
+			want: output{
+				query: `DELETE FROM sessions WHERE expires_at < ?`,
+				vals:  []interface{}{since},
+			},
+		},
+		testcase{
+			name:  "join",
+			query: qb.Join(qb.Select("employees", "id"), qb.Select("dealerships", "name")).On("employees.dealership_id", "dealerships.id").Apply(admins),
+			want: output{
+				query: `SELECT employees.id, dealerships.name FROM employees, dealerships WHERE employees.dealership_id = dealerships.id AND (employees.role = ?)`,
+				vals:  []interface{}{"admin"},
+			},
+		},
+		testcase{
+			name:  "no scopes",
+			query: qb.Select("posts", "id").Apply(),
+			want: output{
+				query: `SELECT id FROM posts`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
@@ -0,0 +1,154 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseSearch converts a search box query of the form
+//
+//	state:NY cost:>10000 "certified pre-owned" -salvage
+//
+// into a condition for a WHERE clause. Terms are separated by whitespace and
+// combined with AND:
+//
+//   - `field:value` matches the field for equality. The value can be prefixed
+//     with >, >=, < or <= to compare instead.
+//   - Any other term, or a phrase in double quotes, matches if textField
+//     contains it, using LIKE.
+//   - A leading `-` negates the term.
+//
+// Values can be quoted to include spaces, e.g. `make:"Land Rover"`. Every field
+// must be present in allowed, which also maps it to its column, and free text
+// is rejected if textField is empty. The constant condition Bool(true) is
+// returned if the search is blank, so the result can always be passed to Where.
+// Values are always bound as strings.
+func ParseSearch(search string, allowed FieldMap, textField string) (Query, error) {
+	terms, err := splitSearch(search)
+	if err != nil {
+		return nil, err
+	}
+
+	var where Query
+	for _, term := range terms {
+		cond, err := searchTerm(term, allowed, textField)
+		if err != nil {
+			return nil, err
+		}
+		if where == nil {
+			where = cond
+		} else {
+			where = And(where, cond)
+		}
+	}
+	if where == nil {
+		return Bool(true), nil
+	}
+	return where, nil
+}
+
+// searchToken is a single term of a search, with any quotes removed.
+type searchToken struct {
+	negated bool
+	field   string
+	value   string
+	quoted  bool
+}
+
+// negations maps each comparison operator to the operator that matches
+// exactly the other non-NULL values.
+var negations = map[string]string{
+	"=":        "<>",
+	"!=":       "=",
+	"<>":       "=",
+	">":        "<=",
+	">=":       "<",
+	"<":        ">=",
+	"<=":       ">",
+	"LIKE":     "NOT LIKE",
+	"NOT LIKE": "LIKE",
+}
+
+func searchTerm(t searchToken, allowed FieldMap, textField string) (Query, error) {
+	if t.field == "" {
+		if textField == "" {
+			return nil, fmt.Errorf("qb: free text search isn't supported: %q", t.value)
+		}
+		op := "LIKE"
+		if t.negated {
+			op = "NOT LIKE"
+		}
+		return ComparisonClause{Op: op, Field: textField, Value: "%" + escapeLike(t.value) + "%"}, nil
+	}
+
+	column, err := allowed.Column(t.field)
+	if err != nil {
+		return nil, err
+	}
+	op, value := "=", t.value
+	if !t.quoted {
+		for _, prefix := range []string{">=", "<=", ">", "<"} {
+			if strings.HasPrefix(value, prefix) {
+				op, value = prefix, value[len(prefix):]
+				break
+			}
+		}
+	}
+	if value == "" {
+		return nil, fmt.Errorf("qb: missing value for %s in search", t.field)
+	}
+	if t.negated {
+		op = negations[op]
+	}
+	return ComparisonClause{Op: op, Field: column, Value: value}, nil
+}
+
+// splitSearch splits a search into terms, keeping quoted phrases and quoted
+// field values together.
+func splitSearch(s string) ([]searchToken, error) {
+	var tokens []searchToken
+	for i := 0; i < len(s); {
+		if s[i] == ' ' || s[i] == '\t' || s[i] == '\n' {
+			i++
+			continue
+		}
+
+		var t searchToken
+		if s[i] == '-' {
+			t.negated = true
+			i++
+		}
+		start := i
+		for i < len(s) && s[i] != ' ' && s[i] != '\t' && s[i] != '\n' && s[i] != '"' {
+			if s[i] == ':' && t.field == "" && i > start {
+				t.field = s[start:i]
+				start = i + 1
+			}
+			i++
+		}
+		if i < len(s) && s[i] == '"' {
+			if i != start {
+				return nil, fmt.Errorf("qb: unexpected quote in search %q", s)
+			}
+			end := strings.IndexByte(s[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("qb: unterminated quote in search %q", s)
+			}
+			t.value, t.quoted = s[i+1:i+1+end], true
+			i += end + 2
+		} else {
+			t.value = s[start:i]
+		}
+		if t.value == "" && t.field == "" {
+			continue
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, nil
+}
+
+// escapeLike escapes the LIKE wildcards in s using the default escape
+// character, so that it is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,59 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestParseSearch(t *testing.T) {
+	fields := qb.FieldMap{"state": "state", "cost": "cost", "make": "make"}
+
+	where, err := qb.ParseSearch(`state:NY cost:>10000 "certified pre-owned" -salvage`, fields, "description")
+	if err != nil {
+		t.Fatal(err)
+	}
+	quoted, err := qb.ParseSearch(`make:"Land Rover" -cost:>=5000 100%`, fields, "description")
+	if err != nil {
+		t.Fatal(err)
+	}
+	blank, err := qb.ParseSearch("   ", fields, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []testcase{
+		testcase{
+			name:  "mixed",
+			query: qb.Select("vehicles", "id").Where(where),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE (((state = ? AND cost > ?) AND description LIKE ?) AND description NOT LIKE ?)`,
+				vals:  []interface{}{"NY", "10000", "%certified pre-owned%", "%salvage%"},
+			},
+		},
+		testcase{
+			name:  "quoted and negated",
+			query: quoted,
+			want: output{
+				query: `((make = ? AND cost < ?) AND description LIKE ?)`,
+				vals:  []interface{}{"Land Rover", "5000", `%100\%%`},
+			},
+		},
+		testcase{
+			name:  "blank",
+			query: qb.Select("vehicles", "id").Where(blank),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE TRUE`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+
+	for _, bad := range []string{"vin:123", "cost:", `make:"Honda`, "salvage"} {
+		if _, err := qb.ParseSearch(bad, fields, ""); err == nil {
+			t.Errorf("expected an error parsing %q", bad)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package qb
+
+import "fmt"
+
+// CreateSequence returns a query that resolves to the general form `CREATE
+// SEQUENCE name [INCREMENT BY n] [START WITH n]`.
+func CreateSequence(name string) CreateSequenceQuery {
+	return CreateSequenceQuery{
+		Name: name,
+	}
+}
+
+// CreateSequenceQuery represents a query that resolves to the general form
+// `CREATE SEQUENCE [IF NOT EXISTS] name [INCREMENT BY n] [START WITH n]`. DDL
+// statements can't take bound parameters, so the options are rendered inline.
+type CreateSequenceQuery struct {
+	Name         string
+	Increment    int64
+	Start        int64
+	HasStart     bool
+	SkipExisting bool
+}
+
+// Build returns a query string of the general form `CREATE SEQUENCE [IF NOT
+// EXISTS] name [INCREMENT BY n] [START WITH n]`.
+func (q CreateSequenceQuery) Build() string {
+	stmt := "CREATE SEQUENCE "
+	if q.SkipExisting {
+		stmt += "IF NOT EXISTS "
+	}
+	stmt += q.Name
+	if q.Increment != 0 {
+		stmt += fmt.Sprintf(" INCREMENT BY %d", q.Increment)
+	}
+	if q.HasStart {
+		stmt += fmt.Sprintf(" START WITH %d", q.Start)
+	}
+	return stmt
+}
+
+func (q CreateSequenceQuery) String() string {
+	return q.Build()
+}
+
+// Values always returns nil for CreateSequenceQuery.
+func (q CreateSequenceQuery) Values() []interface{} {
+	return nil
+}
+
+// ToSql is like SelectQuery.ToSql.
+func (q CreateSequenceQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(q)
+}
+
+// BuildFor is like SelectQuery.BuildFor.
+func (q CreateSequenceQuery) BuildFor(d Dialect) string {
+	return BuildFor(q, d)
+}
+
+// Kind returns KindDDL.
+func (q CreateSequenceQuery) Kind() Kind {
+	return KindDDL
+}
+
+// IfNotExists makes creating the sequence a no-op if it already exists.
+func (q CreateSequenceQuery) IfNotExists() CreateSequenceQuery {
+	q.SkipExisting = true
+	return q
+}
+
+// IncrementBy sets the amount the sequence is advanced by each call to nextval.
+func (q CreateSequenceQuery) IncrementBy(n int64) CreateSequenceQuery {
+	q.Increment = n
+	return q
+}
+
+// StartWith sets the first value returned by the sequence.
+func (q CreateSequenceQuery) StartWith(n int64) CreateSequenceQuery {
+	q.Start = n
+	q.HasStart = true
+	return q
+}
+
+// NextVal returns an expression that resolves to `nextval(?)`, advancing the
+// named sequence and returning its new value. The sequence name is bound as a
+// parameter.
+func NextVal(sequence string) SequenceExpr {
+	return SequenceExpr{
+		Func: "nextval",
+		Args: []interface{}{sequence},
+	}
+}
+
+// CurrVal returns an expression that resolves to `currval(?)`, returning the
+// value most recently obtained by nextval for the named sequence in the
+// current session.
+func CurrVal(sequence string) SequenceExpr {
+	return SequenceExpr{
+		Func: "currval",
+		Args: []interface{}{sequence},
+	}
+}
+
+// SequenceExpr represents a call to one of the sequence manipulation
+// functions. It can be used anywhere a value is expected.
+type SequenceExpr struct {
+	Func string
+	Args []interface{}
+}
+
+// Build returns an expression of the form `func(?, ...)`.
+func (e SequenceExpr) Build() string {
+	return fmt.Sprintf("%s(%s)", e.Func, placeholders(len(e.Args)))
+}
+
+func (e SequenceExpr) String() string {
+	return e.Build()
+}
+
+// Values returns the arguments to the sequence function.
+func (e SequenceExpr) Values() []interface{} {
+	return e.Args
+}
+
+func (e SequenceExpr) scalar() {}
+
+// SetVal returns a query that resolves to `SELECT setval(?, ?)`, resetting the
+// named sequence so that the next call to nextval returns value + 1.
+func SetVal(sequence string, value int64) RawQuery {
+	return Raw("SELECT setval(?, ?)", sequence, value)
+}
@@ -0,0 +1,53 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestSequences(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name:  "create sequence",
+			query: qb.CreateSequence("invoice_numbers"),
+			want: output{
+				query: `CREATE SEQUENCE invoice_numbers`,
+			},
+		},
+		testcase{
+			name:  "create sequence with options",
+			query: qb.CreateSequence("invoice_numbers").IfNotExists().IncrementBy(10).StartWith(1000),
+			want: output{
+				query: `CREATE SEQUENCE IF NOT EXISTS invoice_numbers INCREMENT BY 10 START WITH 1000`,
+			},
+		},
+		testcase{
+			name:  "next value as comparison value",
+			query: qb.Select("invoices", "id").Where(qb.Less("number", qb.NextVal("invoice_numbers"))),
+			want: output{
+				query: `SELECT id FROM invoices WHERE number < nextval(?)`,
+				vals:  []interface{}{"invoice_numbers"},
+			},
+		},
+		testcase{
+			name:  "current value as field",
+			query: qb.Select("invoices").Expr(qb.As(qb.CurrVal("invoice_numbers"), "last")),
+			want: output{
+				query: `SELECT currval(?) AS last FROM invoices`,
+				vals:  []interface{}{"invoice_numbers"},
+			},
+		},
+		testcase{
+			name:  "set value",
+			query: qb.SetVal("invoice_numbers", 5000),
+			want: output{
+				query: `SELECT setval(?, ?)`,
+				vals:  []interface{}{"invoice_numbers", int64(5000)},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
@@ -0,0 +1,96 @@
+package qb
+
+// Simplify returns an equivalent query with a smaller filter tree, which is
+// mostly useful for filters that are generated by machines rather than written
+// by hand. It
+//
+//   - drops nil operands of AND and OR, as well as TRUE operands of AND and
+//     FALSE operands of OR
+//   - reduces AND to FALSE if any operand is FALSE, and OR to TRUE if any
+//     operand is TRUE
+//   - flattens nested ANDs and ORs and removes duplicate operands
+//   - removes double negations and folds NOT TRUE and NOT FALSE
+//
+// The WHERE clauses of SELECT and DELETE queries are simplified too, and are
+// removed entirely if they reduce to TRUE. Other queries are returned as-is.
+func Simplify(q Query) Query {
+	switch q := q.(type) {
+	case BooleanQuery:
+		return simplifyBoolean(q)
+	case NotClause:
+		switch inner := Simplify(q.Query).(type) {
+		case NotClause:
+			return inner.Query
+		case BoolClause:
+			return !inner
+		default:
+			return Not(inner)
+		}
+	case SelectQuery:
+		q.WhereClause, q.Vals = simplifyWhere(q.WhereClause)
+		return q
+	case DeleteQuery:
+		q.WhereClause, q.Vals = simplifyWhere(q.WhereClause)
+		return q
+	case UpdateQuery:
+		q.WhereClause, q.Vals = simplifyWhere(q.WhereClause)
+		return q
+	}
+	return q
+}
+
+func simplifyWhere(where Query) (Query, []interface{}) {
+	if where == nil {
+		return nil, nil
+	}
+	where = Simplify(where)
+	if where == Bool(true) {
+		return nil, nil
+	}
+	return where, where.Values()
+}
+
+func simplifyBoolean(q BooleanQuery) Query {
+	if q.Op != "AND" && q.Op != "OR" {
+		return q
+	}
+	// identity is the operand that doesn't change the result of the operator,
+	// and its negation short-circuits it.
+	identity := Bool(q.Op == "AND")
+
+	var terms []Query
+	seen := make(map[string]bool)
+	var collect func(Query) bool
+	collect = func(t Query) bool {
+		if t == nil {
+			return true
+		}
+		if b, ok := t.(BooleanQuery); ok && b.Op == q.Op {
+			return collect(b.Comparison1) && collect(b.Comparison2)
+		}
+		t = Simplify(t)
+		if b, ok := t.(BooleanQuery); ok && b.Op == q.Op {
+			return collect(b.Comparison1) && collect(b.Comparison2)
+		}
+		if b, ok := t.(BoolClause); ok {
+			return b == identity
+		}
+		if key := Dump(t); !seen[key] {
+			seen[key] = true
+			terms = append(terms, t)
+		}
+		return true
+	}
+	if !collect(q.Comparison1) || !collect(q.Comparison2) {
+		return !identity
+	}
+
+	if len(terms) == 0 {
+		return identity
+	}
+	result := terms[0]
+	for _, t := range terms[1:] {
+		result = BooleanQuery{Op: q.Op, Comparison1: result, Comparison2: t}
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestSimplify(t *testing.T) {
+	honda := qb.Equal("make", "Honda")
+	cheap := qb.Less("cost", 20000)
+	recent := qb.Greater("year", 2015)
+
+	testcases := []testcase{
+		testcase{
+			name:  "tautology",
+			query: qb.Simplify(qb.And(qb.Bool(true), honda)),
+			want: output{
+				query: `make = ?`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name:  "contradiction",
+			query: qb.Simplify(qb.Or(qb.And(honda, qb.Bool(false)), cheap)),
+			want: output{
+				query: `cost < ?`,
+				vals:  []interface{}{20000},
+			},
+		},
+		testcase{
+			name:  "double negation",
+			query: qb.Simplify(qb.Not(qb.Not(qb.And(honda, qb.Bool(true))))),
+			want: output{
+				query: `make = ?`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name:  "negated constant",
+			query: qb.Simplify(qb.Not(qb.Or(honda, qb.Bool(true)))),
+			want: output{
+				query: `FALSE`,
+			},
+		},
+		testcase{
+			name:  "short circuit",
+			query: qb.Simplify(qb.Or(honda, qb.Or(cheap, qb.Bool(true)))),
+			want: output{
+				query: `TRUE`,
+			},
+		},
+		testcase{
+			name:  "flatten and dedupe",
+			query: qb.Simplify(qb.And(qb.And(honda, cheap), qb.And(recent, qb.And(honda, nil)))),
+			want: output{
+				query: `((make = ? AND cost < ?) AND year > ?)`,
+				vals:  []interface{}{"Honda", 20000, 2015},
+			},
+		},
+		testcase{
+			name:  "self comparison is kept for NULLs",
+			query: qb.Simplify(qb.Not(qb.Less("cost", qb.Col("cost")))),
+			want: output{
+				query: `NOT (cost < cost)`,
+			},
+		},
+		testcase{
+			name:  "select",
+			query: qb.Simplify(qb.Select("vehicles", "id").Where(qb.And(qb.Bool(true), qb.Bool(true)))),
+			want: output{
+				query: `SELECT id FROM vehicles`,
+			},
+		},
+		testcase{
+			name:  "delete",
+			query: qb.Simplify(qb.Delete("vehicles").Where(qb.And(qb.Bool(true), cheap))),
+			want: output{
+				query: `DELETE FROM vehicles WHERE cost < ?`,
+				vals:  []interface{}{20000},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
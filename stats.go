@@ -0,0 +1,120 @@
+package qb
+
+import "fmt"
+
+// Stddev returns an aggregate expression that resolves to the form
+// `stddev(field)`, the sample standard deviation of a column.
+func Stddev(field string) AggregateClause {
+	return AggregateClause{
+		Func:  "stddev",
+		Field: field,
+	}
+}
+
+// Variance returns an aggregate expression that resolves to the form
+// `variance(field)`, the sample variance of a column.
+func Variance(field string) AggregateClause {
+	return AggregateClause{
+		Func:  "variance",
+		Field: field,
+	}
+}
+
+// PercentileCont returns an aggregate expression that resolves to the form
+// `percentile_cont(?) WITHIN GROUP (ORDER BY field)`, the value at the given
+// fraction of the sorted column, interpolating between adjacent values if
+// needed.
+func PercentileCont(fraction float64, field string) PercentileClause {
+	return PercentileClause{
+		Func:     "percentile_cont",
+		Fraction: fraction,
+		Field:    field,
+	}
+}
+
+// PercentileDisc is like PercentileCont, but returns the first value whose
+// position in the sorted column is at or after the fraction rather than
+// interpolating.
+func PercentileDisc(fraction float64, field string) PercentileClause {
+	return PercentileClause{
+		Func:     "percentile_disc",
+		Fraction: fraction,
+		Field:    field,
+	}
+}
+
+// PercentileClause represents an ordered-set aggregate over a single column.
+type PercentileClause struct {
+	Func     string
+	Fraction float64
+	Field    string
+}
+
+// Build returns an expression of the form `fn(?) WITHIN GROUP (ORDER BY
+// field)`.
+func (c PercentileClause) Build() string {
+	return fmt.Sprintf("%s(?) WITHIN GROUP (ORDER BY %s)", c.Func, c.Field)
+}
+
+func (c PercentileClause) String() string {
+	return c.Build()
+}
+
+// Values returns the fraction.
+func (c PercentileClause) Values() []interface{} {
+	return []interface{}{c.Fraction}
+}
+
+func (c PercentileClause) scalar() {}
+
+// Median returns an aggregate expression that resolves to the form
+// `percentile_cont(0.5) WITHIN GROUP (ORDER BY field)`. Use
+// WithoutPercentileCont on databases without ordered-set aggregates, such as
+// MySQL.
+func Median(field string) MedianExpr {
+	return MedianExpr{
+		Field: field,
+	}
+}
+
+// MedianExpr represents the median of a numeric column.
+type MedianExpr struct {
+	Field    string
+	Emulated bool
+}
+
+// WithoutPercentileCont computes the median by picking the middle values out
+// of a sorted GROUP_CONCAT of the column, as MySQL has no percentile_cont. The
+// result is a DOUBLE and is subject to group_concat_max_len, so it is only
+// suitable for modest groups.
+func (e MedianExpr) WithoutPercentileCont() MedianExpr {
+	e.Emulated = true
+	return e
+}
+
+// Build returns an expression of the form `percentile_cont(0.5) WITHIN GROUP
+// (ORDER BY field)`, or the average of the middle two elements of
+// `GROUP_CONCAT(field ORDER BY field)` if emulated. For an odd number of rows
+// both elements are the same.
+func (e MedianExpr) Build() string {
+	if e.Emulated {
+		nth := func(n string) string {
+			return fmt.Sprintf("SUBSTRING_INDEX(SUBSTRING_INDEX(GROUP_CONCAT(%[1]s ORDER BY %[1]s), ',', %[2]s), ',', -1)", e.Field, n)
+		}
+		lower := nth(fmt.Sprintf("FLOOR((COUNT(%s) + 1) / 2)", e.Field))
+		upper := nth(fmt.Sprintf("FLOOR(COUNT(%s) / 2) + 1", e.Field))
+		return fmt.Sprintf("(%s + %s) / 2", lower, upper)
+	}
+	return fmt.Sprintf("percentile_cont(0.5) WITHIN GROUP (ORDER BY %s)", e.Field)
+}
+
+func (e MedianExpr) String() string {
+	return e.Build()
+}
+
+// Values always returns nil for MedianExpr.
+func (e MedianExpr) Values() []interface{} {
+	return nil
+}
+
+func (e MedianExpr) scalar() {}
@@ -0,0 +1,44 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestStatsAggregates(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name:  "stddev and variance",
+			query: qb.Select("vehicles", "make").Expr(qb.Stddev("cost"), qb.Variance("cost")).GroupBy("make"),
+			want: output{
+				query: `SELECT make, stddev(cost), variance(cost) FROM vehicles GROUP BY make`,
+			},
+		},
+		testcase{
+			name:  "percentile",
+			query: qb.Select("vehicles").Expr(qb.As(qb.PercentileCont(0.9, "cost"), "p90"), qb.PercentileDisc(0.5, "year")),
+			want: output{
+				query: `SELECT percentile_cont(?) WITHIN GROUP (ORDER BY cost) AS p90, percentile_disc(?) WITHIN GROUP (ORDER BY year) FROM vehicles`,
+				vals:  []interface{}{0.9, 0.5},
+			},
+		},
+		testcase{
+			name:  "median",
+			query: qb.Select("vehicles").Expr(qb.Median("cost")),
+			want: output{
+				query: `SELECT percentile_cont(0.5) WITHIN GROUP (ORDER BY cost) FROM vehicles`,
+			},
+		},
+		testcase{
+			name:  "emulated median",
+			query: qb.Select("vehicles").Expr(qb.Median("cost").WithoutPercentileCont()),
+			want: output{
+				query: `SELECT (SUBSTRING_INDEX(SUBSTRING_INDEX(GROUP_CONCAT(cost ORDER BY cost), ',', FLOOR((COUNT(cost) + 1) / 2)), ',', -1) + SUBSTRING_INDEX(SUBSTRING_INDEX(GROUP_CONCAT(cost ORDER BY cost), ',', FLOOR(COUNT(cost) / 2) + 1), ',', -1)) / 2 FROM vehicles`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
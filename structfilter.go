@@ -0,0 +1,122 @@
+package qb
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FilterFromStruct builds a condition for a WHERE clause from a filter struct,
+// the typed alternative to ParseSearch and GraphQLSelect. Each field tagged
+// with `qb:"name,op"` is compared with the column of the same name using op,
+// which is one of eq, ne, gt, gte, lt, lte, like or in. A `column=` option
+// compares a different column instead, so that several fields can filter the
+// same one:
+//
+//	type VehicleFilter struct {
+//		State   string   `qb:"state,eq"`
+//		MinCost *int     `qb:"min_cost,gte,column=cost"`
+//		MaxCost *int     `qb:"max_cost,lte,column=cost"`
+//		Makes   []string `qb:"makes,in,column=make"`
+//	}
+//
+// The conditions are combined with AND in field order. Fields with a zero
+// value, nil pointers and empty slices are skipped; use a pointer to filter on
+// a zero value. An in field must be a slice or array, whose elements are bound
+// as separate values. The constant condition Bool(true) is returned if every
+// field is skipped or v is a nil pointer, so the result can always be passed to
+// Where. An error is returned if v isn't a struct or a pointer to one, or a tag
+// is malformed.
+func FilterFromStruct(v interface{}) (Query, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return Bool(true), nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("qb: filter must be a struct, got %T", v)
+	}
+
+	var where Query
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		tag, ok := f.Tag.Lookup("qb")
+		if !ok || tag == "-" || f.PkgPath != "" {
+			continue
+		}
+		cond, err := structCondition(tag, rv.Field(i))
+		if err != nil {
+			return nil, fmt.Errorf("qb: filter field %s: %w", f.Name, err)
+		}
+		if cond == nil {
+			continue
+		}
+		if where == nil {
+			where = cond
+		} else {
+			where = And(where, cond)
+		}
+	}
+	if where == nil {
+		return Bool(true), nil
+	}
+	return where, nil
+}
+
+// structOps maps the operators of filter struct tags to comparison operators.
+var structOps = map[string]string{
+	"eq":   "=",
+	"ne":   "!=",
+	"gt":   ">",
+	"gte":  ">=",
+	"lt":   "<",
+	"lte":  "<=",
+	"like": "LIKE",
+}
+
+// structCondition returns the condition for a single tagged field, or nil if
+// the field should be skipped.
+func structCondition(tag string, v reflect.Value) (Query, error) {
+	parts := strings.Split(tag, ",")
+	if len(parts) < 2 || parts[0] == "" {
+		return nil, fmt.Errorf("malformed tag %q", tag)
+	}
+	column, op := parts[0], parts[1]
+	for _, opt := range parts[2:] {
+		if !strings.HasPrefix(opt, "column=") || opt == "column=" {
+			return nil, fmt.Errorf("unknown option %q", opt)
+		}
+		column = strings.TrimPrefix(opt, "column=")
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	} else if v.IsZero() {
+		return nil, nil
+	}
+
+	if op == "in" {
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return nil, fmt.Errorf("in requires a slice, got %s", v.Type())
+		}
+		if v.Len() == 0 {
+			return nil, nil
+		}
+		vals := make([]interface{}, v.Len())
+		for i := range vals {
+			vals[i] = v.Index(i).Interface()
+		}
+		return In(column, vals...), nil
+	}
+	sqlOp, ok := structOps[op]
+	if !ok {
+		return nil, fmt.Errorf("unknown operator %q", op)
+	}
+	return ComparisonClause{Op: sqlOp, Field: column, Value: v.Interface()}, nil
+}
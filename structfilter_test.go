@@ -0,0 +1,89 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+type vehicleFilter struct {
+	State   string   `qb:"state,eq"`
+	MinCost *int     `qb:"min_cost,gte,column=cost"`
+	MaxCost *int     `qb:"max_cost,lte,column=cost"`
+	Makes   []string `qb:"makes,in,column=make"`
+	Model   string   `qb:"model,like"`
+	Page    int
+}
+
+func TestFilterFromStruct(t *testing.T) {
+	zero, max := 0, 20000
+
+	testcases := []struct {
+		name   string
+		filter interface{}
+		want   output
+	}{
+		{
+			name:   "all fields",
+			filter: vehicleFilter{State: "NY", MinCost: &zero, MaxCost: &max, Makes: []string{"Honda", "Acura"}, Model: "C%", Page: 2},
+			want: output{
+				query: `SELECT id FROM vehicles WHERE ((((state = ? AND cost >= ?) AND cost <= ?) AND make IN (?, ?)) AND model LIKE ?)`,
+				vals:  []interface{}{"NY", 0, 20000, "Honda", "Acura", "C%"},
+			},
+		},
+		{
+			name:   "zero fields skipped",
+			filter: &vehicleFilter{MaxCost: &max, Makes: []string{}},
+			want: output{
+				query: `SELECT id FROM vehicles WHERE cost <= ?`,
+				vals:  []interface{}{20000},
+			},
+		},
+		{
+			name:   "empty",
+			filter: vehicleFilter{},
+			want: output{
+				query: `SELECT id FROM vehicles WHERE TRUE`,
+			},
+		},
+		{
+			name:   "nil pointer",
+			filter: (*vehicleFilter)(nil),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE TRUE`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		where, err := qb.FilterFromStruct(tc.filter)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		q := qb.Select("vehicles", "id").Where(where)
+		t.Run(tc.name, test(testcase{name: tc.name, query: q, want: tc.want}))
+	}
+}
+
+func TestFilterFromStructErrors(t *testing.T) {
+	testcases := []struct {
+		name   string
+		filter interface{}
+	}{
+		{"not a struct", "state"},
+		{"unknown operator", struct {
+			State string `qb:"state,is"`
+		}{"NY"}},
+		{"missing operator", struct {
+			State string `qb:"state"`
+		}{"NY"}},
+		{"in without slice", struct {
+			State string `qb:"state,in"`
+		}{"NY"}},
+	}
+	for _, tc := range testcases {
+		if _, err := qb.FilterFromStruct(tc.filter); err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
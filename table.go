@@ -0,0 +1,66 @@
+package qb
+
+import "fmt"
+
+// CreateTableAs returns a query that resolves to the form `CREATE TABLE name AS
+// select`, creating a table from the results of the query.
+func CreateTableAs(name string, q SelectQuery) CreateTableAsQuery {
+	return CreateTableAsQuery{
+		Name:  name,
+		Query: q,
+	}
+}
+
+// CreateTempTableAs returns a query that resolves to the form `CREATE TEMPORARY
+// TABLE name AS select`. Temporary tables are dropped at the end of the
+// session, which makes them useful for staging intermediate results in
+// multi-step workflows.
+func CreateTempTableAs(name string, q SelectQuery) CreateTableAsQuery {
+	return CreateTableAsQuery{
+		Name:      name,
+		Temporary: true,
+		Query:     q,
+	}
+}
+
+// CreateTableAsQuery represents a query that resolves to the general form
+// `CREATE [TEMPORARY] TABLE name AS select`.
+type CreateTableAsQuery struct {
+	Name      string
+	Temporary bool
+	Query     SelectQuery
+}
+
+// Build returns a query string of the general form `CREATE [TEMPORARY] TABLE
+// name AS select`.
+func (q CreateTableAsQuery) Build() string {
+	kind := "TABLE"
+	if q.Temporary {
+		kind = "TEMPORARY TABLE"
+	}
+	return fmt.Sprintf("CREATE %s %s AS %s", kind, q.Name, q.Query.Build())
+}
+
+func (q CreateTableAsQuery) String() string {
+	return q.Build()
+}
+
+// Values returns the values for the underlying SELECT.
+func (q CreateTableAsQuery) Values() []interface{} {
+	return q.Query.Values()
+}
+
+// ToSql is like SelectQuery.ToSql.
+func (q CreateTableAsQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(q)
+}
+
+// BuildFor is like SelectQuery.BuildFor.
+func (q CreateTableAsQuery) BuildFor(d Dialect) string {
+	return BuildFor(q, d)
+}
+
+// Kind returns KindDDL.
+func (q CreateTableAsQuery) Kind() Kind {
+	return KindDDL
+}
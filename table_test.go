@@ -0,0 +1,48 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestCreateTableAs(t *testing.T) {
+	hondas := qb.Select("vehicles", "id", "cost").Where(qb.Equal("make", "Honda"))
+
+	testcases := []testcase{
+		testcase{
+			name:  "create table as",
+			query: qb.CreateTableAs("honda_snapshot", hondas),
+			want: output{
+				query: `CREATE TABLE honda_snapshot AS SELECT id, cost FROM vehicles WHERE make = ?`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name:  "create temp table as",
+			query: qb.CreateTempTableAs("hondas", hondas),
+			want: output{
+				query: `CREATE TEMPORARY TABLE hondas AS SELECT id, cost FROM vehicles WHERE make = ?`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name:  "select into temp",
+			query: hondas.IntoTemp("hondas"),
+			want: output{
+				query: `SELECT id, cost INTO TEMP hondas FROM vehicles WHERE make = ?`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name:  "select into",
+			query: qb.Select("vehicles").Into("vehicles_backup"),
+			want: output{
+				query: `SELECT * INTO vehicles_backup FROM vehicles`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
@@ -0,0 +1,133 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TableFunc returns a relation that resolves to the form `fn(?, ?) AS alias`,
+// for set-returning functions that don't have a dedicated constructor. Use it
+// with SelectFrom or as the second half of a join.
+func TableFunc(alias, fn string, args ...interface{}) TableFuncClause {
+	return TableFuncClause{
+		Func:  fn,
+		Args:  args,
+		Alias: alias,
+	}
+}
+
+// GenerateSeries returns a relation that resolves to the form
+// `generate_series(?, ?) AS alias`, which is most useful for building a
+// calendar to join against when filling gaps in a report e.g.
+//
+//	qb.GenerateSeries("day", start, end).Step(qb.Raw("interval '1 day'"))
+func GenerateSeries(alias string, start, stop interface{}) TableFuncClause {
+	return TableFunc(alias, "generate_series", start, stop)
+}
+
+// Unnest returns a relation that resolves to the form `unnest(?) AS alias`,
+// expanding an array into one row per element.
+func Unnest(alias string, array interface{}) TableFuncClause {
+	return TableFunc(alias, "unnest", array)
+}
+
+// TableFuncClause represents a call to a set-returning function used as a
+// relation. Arguments that are queries are rendered in place, like the value of
+// a comparison; everything else is bound as a parameter.
+type TableFuncClause struct {
+	Func       string
+	Args       []interface{}
+	Ordinality bool
+	Alias      string
+	Columns    []string
+}
+
+// Step appends the increment argument of generate_series.
+func (c TableFuncClause) Step(step interface{}) TableFuncClause {
+	c.Args = append(c.Args[:len(c.Args):len(c.Args)], step)
+	return c
+}
+
+// WithOrdinality adds a column numbering the rows produced by the function,
+// starting at 1. The column is named `ordinality` unless WithColumns is used to
+// name it.
+func (c TableFuncClause) WithOrdinality() TableFuncClause {
+	c.Ordinality = true
+	return c
+}
+
+// WithColumns names the columns produced by the function, including the
+// ordinality column if there is one.
+func (c TableFuncClause) WithColumns(columns ...string) TableFuncClause {
+	c.Columns = columns
+	return c
+}
+
+// Build returns a relation of the form `fn(?, ?) [WITH ORDINALITY] AS
+// alias[(columns)]`.
+func (c TableFuncClause) Build() string {
+	args := make([]string, 0, len(c.Args))
+	for _, arg := range c.Args {
+		args = append(args, buildArg(arg))
+	}
+	rel := fmt.Sprintf("%s(%s)", c.Func, strings.Join(args, ", "))
+	if c.Ordinality {
+		rel += " WITH ORDINALITY"
+	}
+	rel += " AS " + c.Alias
+	if len(c.Columns) > 0 {
+		rel += "(" + strings.Join(c.Columns, ", ") + ")"
+	}
+	return rel
+}
+
+func (c TableFuncClause) String() string {
+	return c.Build()
+}
+
+// Values returns the values of the arguments in order.
+func (c TableFuncClause) Values() []interface{} {
+	var vals []interface{}
+	for _, arg := range c.Args {
+		vals = append(vals, argValues(arg)...)
+	}
+	return vals
+}
+
+func (c TableFuncClause) name() string {
+	return c.Alias
+}
+
+// columns returns the names of the columns produced by the function. Without
+// explicit names, a single-column function takes the name of its alias.
+func (c TableFuncClause) columns() []string {
+	if len(c.Columns) > 0 {
+		return c.Columns
+	}
+	cols := []string{c.Alias}
+	if c.Ordinality {
+		cols = append(cols, "ordinality")
+	}
+	return cols
+}
+
+// buildArg renders a function argument the same way as the value of a
+// comparison.
+func buildArg(v interface{}) string {
+	v = valueOf(v)
+	if e, ok := v.(scalar); ok {
+		return e.(Query).Build()
+	}
+	if q, ok := v.(Query); ok {
+		return "(" + q.Build() + ")"
+	}
+	return "?"
+}
+
+func argValues(v interface{}) []interface{} {
+	v = valueOf(v)
+	if q, ok := v.(Query); ok {
+		return q.Values()
+	}
+	return []interface{}{v}
+}
@@ -0,0 +1,52 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestTableFunc(t *testing.T) {
+	days := qb.GenerateSeries("day", "2021-01-01", "2021-01-31").Step(qb.Raw("interval '1 day'"))
+
+	testcases := []testcase{
+		testcase{
+			name:  "generate series",
+			query: qb.SelectFrom(days, "day"),
+			want: output{
+				query: `SELECT day FROM generate_series(?, ?, (interval '1 day')) AS day`,
+				vals:  []interface{}{"2021-01-01", "2021-01-31"},
+			},
+		},
+		testcase{
+			name: "calendar join",
+			query: qb.Join(
+				qb.SelectFrom(days, "day"),
+				qb.Select("orders", "id"),
+			).On("day.day", "orders.placed_on"),
+			want: output{
+				query: `SELECT day.day, orders.id FROM generate_series(?, ?, (interval '1 day')) AS day, orders WHERE day.day = orders.placed_on`,
+				vals:  []interface{}{"2021-01-01", "2021-01-31"},
+			},
+		},
+		testcase{
+			name: "unnest with ordinality",
+			query: qb.SelectFrom(qb.Unnest("t", []string{"a", "b"}).WithOrdinality().WithColumns("tag", "position"), "tag", "position").
+				Where(qb.Greater("position", 1)),
+			want: output{
+				query: `SELECT tag, position FROM unnest(?) WITH ORDINALITY AS t(tag, position) WHERE position > ?`,
+				vals:  []interface{}{[]string{"a", "b"}, 1},
+			},
+		},
+		testcase{
+			name:  "generic",
+			query: qb.SelectFrom(qb.TableFunc("e", "jsonb_array_elements", qb.Col("payload"))),
+			want: output{
+				query: `SELECT * FROM jsonb_array_elements(payload) AS e`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
@@ -0,0 +1,163 @@
+package qb
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+)
+
+var holePattern = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// Template returns a query built from a hand-written SQL skeleton containing
+// named holes of the form `{{name}}`. Fragments built with qb are injected into
+// the holes when the query is built, so the main statement can be owned by
+// whoever writes the SQL while the dynamic parts are owned by the application.
+// The skeleton itself should not contain any placeholders; all values must come
+// from the fragments.
+func Template(sql string) TemplateQuery {
+	return TemplateQuery{
+		SQL: sql,
+	}
+}
+
+// ParseTemplate reads a template skeleton from a file. This is typically used
+// with an embed.FS containing the application's .sql files.
+func ParseTemplate(fsys fs.FS, path string) (TemplateQuery, error) {
+	b, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return TemplateQuery{}, fmt.Errorf("qb: read template: %w", err)
+	}
+	return Template(string(b)), nil
+}
+
+// TemplateQuery represents a SQL skeleton with named holes that are filled by
+// other queries. The `{{where}}` and `{{order}}` holes are kept separately so
+// that their keywords are only added when the query is built.
+type TemplateQuery struct {
+	SQL         string
+	Holes       map[string]Query
+	WhereClause Query
+	Ordering    OrderByClause
+}
+
+// Set fills the named hole with the query. The query is injected verbatim, so
+// the skeleton is responsible for any surrounding keywords.
+func (t TemplateQuery) Set(name string, q Query) TemplateQuery {
+	holes := make(map[string]Query, len(t.Holes)+1)
+	for k, v := range t.Holes {
+		holes[k] = v
+	}
+	holes[name] = q
+	t.Holes = holes
+	return t
+}
+
+// Where fills the `{{where}}` hole with a clause of the form `WHERE expr`.
+// Calling Where again combines the conditions with AND. Leaving the hole unset
+// removes the WHERE clause entirely.
+func (t TemplateQuery) Where(wq Query) TemplateQuery {
+	if t.WhereClause == nil {
+		t.WhereClause = wq
+	} else {
+		t.WhereClause = And(t.WhereClause, wq)
+	}
+	return t
+}
+
+// OrWhere is like Where, but combines the condition with any existing one
+// using OR.
+func (t TemplateQuery) OrWhere(wq Query) TemplateQuery {
+	if t.WhereClause == nil {
+		t.WhereClause = wq
+	} else {
+		t.WhereClause = Or(t.WhereClause, wq)
+	}
+	return t
+}
+
+// Sort fills the `{{order}}` hole with a clause of the form `ORDER BY terms`.
+// Leaving the hole unset removes the ORDER BY clause entirely.
+func (t TemplateQuery) Sort(o OrderByClause) TemplateQuery {
+	t.Ordering = o
+	return t
+}
+
+// Build returns the skeleton with every hole replaced by its built fragment.
+// Holes that haven't been set are replaced with an empty string.
+func (t TemplateQuery) Build() string {
+	return holePattern.ReplaceAllStringFunc(t.SQL, func(hole string) string {
+		name := holePattern.FindStringSubmatch(hole)[1]
+		if q, ok := t.hole(name); ok {
+			return q.Build()
+		}
+		return ""
+	})
+}
+
+func (t TemplateQuery) String() string {
+	return t.Build()
+}
+
+// Values returns the values for each filled hole in the order the holes appear
+// in the skeleton. Holes that appear more than once contribute their values
+// each time.
+func (t TemplateQuery) Values() []interface{} {
+	var vals []interface{}
+	for _, m := range holePattern.FindAllStringSubmatch(t.SQL, -1) {
+		if q, ok := t.hole(m[1]); ok {
+			vals = append(vals, q.Values()...)
+		}
+	}
+	return vals
+}
+
+// ToSql is like SelectQuery.ToSql.
+func (t TemplateQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(t)
+}
+
+// BuildFor is like SelectQuery.BuildFor.
+func (t TemplateQuery) BuildFor(d Dialect) string {
+	return BuildFor(t, d)
+}
+
+// Kind returns KindRaw, since the template isn't parsed.
+func (t TemplateQuery) Kind() Kind {
+	return KindRaw
+}
+
+// hole returns the query filling the named hole, if any. The WHERE and ORDER BY
+// clauses take precedence over a hole of the same name set with Set.
+func (t TemplateQuery) hole(name string) (Query, bool) {
+	switch {
+	case name == "where" && t.WhereClause != nil:
+		return keywordClause{Keyword: "WHERE", Query: t.WhereClause}, true
+	case name == "order" && len(t.Ordering) > 0:
+		return keywordClause{Keyword: "ORDER BY", Query: t.Ordering}, true
+	}
+	q, ok := t.Holes[name]
+	return q, ok
+}
+
+// keywordClause prefixes a fragment with a keyword such as WHERE. Fragments
+// that build to an empty string are omitted along with the keyword.
+type keywordClause struct {
+	Keyword string
+	Query   Query
+}
+
+func (c keywordClause) Build() string {
+	stmt := c.Query.Build()
+	if stmt == "" {
+		return ""
+	}
+	return c.Keyword + " " + stmt
+}
+
+func (c keywordClause) String() string {
+	return c.Build()
+}
+
+func (c keywordClause) Values() []interface{} {
+	return c.Query.Values()
+}
@@ -0,0 +1,77 @@
+package qb_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestTemplateQuery(t *testing.T) {
+	skeleton := "SELECT id FROM vehicles {{where}} {{ order }}"
+	sort := qb.OrderByClause{{Field: "id", Dir: qb.Desc}}
+
+	testcases := []testcase{
+		testcase{
+			name:  "empty holes",
+			query: qb.Template(skeleton),
+			want: output{
+				query: `SELECT id FROM vehicles  `,
+			},
+		},
+		testcase{
+			name: "filled holes",
+			query: qb.Template(skeleton).
+				Sort(sort).
+				Where(qb.And(qb.Equal("make", "Honda"), qb.Less("cost", 10))),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE (make = ? AND cost < ?) ORDER BY id DESC`,
+				vals:  []interface{}{"Honda", 10},
+			},
+		},
+		testcase{
+			name: "accumulated where",
+			query: qb.Template(skeleton).
+				Where(qb.Equal("make", "Honda")).
+				Where(qb.Less("cost", 10)).
+				OrWhere(qb.Equal("featured", true)),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE ((make = ? AND cost < ?) OR featured = ?) `,
+				vals:  []interface{}{"Honda", 10, true},
+			},
+		},
+		testcase{
+			name: "custom holes in order of appearance",
+			query: qb.Template("SELECT {{a}} FROM t WHERE {{b}} OR {{a}}").
+				Set("b", qb.Equal("y", 2)).
+				Set("a", qb.Equal("x", 1)),
+			want: output{
+				query: `SELECT x = ? FROM t WHERE y = ? OR x = ?`,
+				vals:  []interface{}{1, 2, 1},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
+
+func TestParseTemplate(t *testing.T) {
+	tmpl, err := qb.ParseTemplate(os.DirFS("testdata"), "vehicles_report.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc := testcase{
+		name:  "template file",
+		query: tmpl.Where(qb.Equal("v.make", "Honda")),
+		want: output{
+			query: "SELECT v.id, v.make, d.name\nFROM vehicles v\nJOIN dealerships d ON d.id = v.dealership_id\nWHERE v.make = ?\n\n",
+			vals:  []interface{}{"Honda"},
+		},
+	}
+	t.Run(tc.name, test(tc))
+
+	if _, err := qb.ParseTemplate(os.DirFS("testdata"), "missing.sql"); err == nil {
+		t.Error("expected an error reading a missing template")
+	}
+}
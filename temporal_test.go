@@ -0,0 +1,49 @@
+package qb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestAsOf(t *testing.T) {
+	qb.RegisterTable(qb.TableMeta{
+		Name:         "prices",
+		HistoryTable: "prices_history",
+	})
+	at := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	testcases := []testcase{
+		testcase{
+			name:  "system versioned",
+			query: qb.Select("vehicles", "id", "cost").Where(qb.Equal("make", "Honda")).AsOf(at),
+			want: output{
+				query: `SELECT id, cost FROM vehicles FOR SYSTEM_TIME AS OF ? WHERE make = ?`,
+				vals:  []interface{}{at, "Honda"},
+			},
+		},
+		testcase{
+			name:  "history table",
+			query: qb.Select("prices", "amount").Where(qb.Equal("vehicle_id", 1)).AsOf(at),
+			want: output{
+				query: `SELECT amount FROM (SELECT * FROM prices WHERE sys_period @> CAST(? AS timestamptz) UNION ALL SELECT * FROM prices_history WHERE sys_period @> CAST(? AS timestamptz)) AS prices WHERE vehicle_id = ?`,
+				vals:  []interface{}{at, at, 1},
+			},
+		},
+		testcase{
+			name: "join",
+			query: qb.Join(
+				qb.Select("vehicles", "id").AsOf(at),
+				qb.Select("prices", "amount").AsOf(at),
+			).On("vehicles.id", "prices.vehicle_id"),
+			want: output{
+				query: `SELECT vehicles.id, prices.amount FROM vehicles FOR SYSTEM_TIME AS OF ?, (SELECT * FROM prices WHERE sys_period @> CAST(? AS timestamptz) UNION ALL SELECT * FROM prices_history WHERE sys_period @> CAST(? AS timestamptz)) AS prices WHERE vehicles.id = prices.vehicle_id`,
+				vals:  []interface{}{at, at, at},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
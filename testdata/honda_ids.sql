@@ -0,0 +1,3 @@
+SELECT id
+FROM vehicles
+WHERE make = ?;
@@ -0,0 +1,5 @@
+SELECT v.id, v.make, d.name
+FROM vehicles v
+JOIN dealerships d ON d.id = v.dealership_id
+{{where}}
+{{order}}
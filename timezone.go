@@ -0,0 +1,59 @@
+package qb
+
+import "fmt"
+
+// AtTimeZone returns an expression that resolves to the form `field AT TIME
+// ZONE ?`, converting a timestamp to the local time of the named zone, e.g.
+// `America/New_York`. The zone is bound as a parameter. BuildFor writes it in
+// the form of the dialect; see TimeZoneExpr.Build.
+func AtTimeZone(field, tz string) TimeZoneExpr {
+	return TimeZoneExpr{
+		Field: field,
+		Zone:  tz,
+	}
+}
+
+// AtTimeZoneFor is like AtTimeZone, but builds the conversion for the dialect
+// even when the query it is used in is built with Build rather than BuildFor.
+func AtTimeZoneFor(d Dialect, field, tz string) TimeZoneExpr {
+	return TimeZoneExpr{
+		Field:   field,
+		Zone:    tz,
+		dialect: d,
+	}
+}
+
+// TimeZoneExpr represents a timestamp converted to another time zone.
+type TimeZoneExpr struct {
+	Field string
+	Zone  string
+
+	dialect Dialect
+}
+
+// Build returns an expression of the form `field AT TIME ZONE ?`. MySQL doesn't
+// support AT TIME ZONE, so it gets the form `CONVERT_TZ(field, 'UTC', ?)`,
+// which assumes that the timestamp is stored in UTC and requires the time zone
+// tables to be loaded for named zones.
+func (e TimeZoneExpr) Build() string {
+	if dialectName(e.dialect) == "mysql" {
+		return fmt.Sprintf("CONVERT_TZ(%s, 'UTC', ?)", e.Field)
+	}
+	return fmt.Sprintf("%s AT TIME ZONE ?", e.Field)
+}
+
+func (e TimeZoneExpr) String() string {
+	return e.Build()
+}
+
+// Values returns the zone.
+func (e TimeZoneExpr) Values() []interface{} {
+	return []interface{}{e.Zone}
+}
+
+func (e TimeZoneExpr) scalar() {}
+
+func (e TimeZoneExpr) withDialect(d Dialect) interface{} {
+	e.dialect = d
+	return e
+}
@@ -0,0 +1,59 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestAtTimeZone(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name:  "select",
+			query: qb.Select("orders", "id").Expr(qb.As(qb.AtTimeZone("placed_at", "America/New_York"), "local_placed_at")),
+			want: output{
+				query: `SELECT id, placed_at AT TIME ZONE ? AS local_placed_at FROM orders`,
+				vals:  []interface{}{"America/New_York"},
+			},
+		},
+		testcase{
+			name:  "compare",
+			query: qb.Less("placed_at", qb.AtTimeZone("now()", "UTC")),
+			want: output{
+				query: `placed_at < now() AT TIME ZONE ?`,
+				vals:  []interface{}{"UTC"},
+			},
+		},
+		testcase{
+			name:  "mysql",
+			query: qb.AtTimeZoneFor(qb.MySQL, "placed_at", "America/New_York"),
+			want: output{
+				query: `CONVERT_TZ(placed_at, 'UTC', ?)`,
+				vals:  []interface{}{"America/New_York"},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
+
+func TestAtTimeZoneBuildFor(t *testing.T) {
+	q := qb.Select("orders", "id").
+		Expr(qb.As(qb.AtTimeZone("placed_at", "America/New_York"), "local_placed_at")).
+		Where(qb.Equal("state", "NY"))
+
+	testcases := []struct {
+		dialect qb.Dialect
+		want    string
+	}{
+		{qb.Postgres, `SELECT id, placed_at AT TIME ZONE $1 AS local_placed_at FROM orders WHERE state = $2`},
+		{qb.MySQL, `SELECT id, CONVERT_TZ(placed_at, 'UTC', ?) AS local_placed_at FROM orders WHERE state = ?`},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.dialect.Name(), testFor(tc.dialect, testcase{
+			query: q,
+			want:  output{query: tc.want, vals: []interface{}{"America/New_York", "NY"}},
+		}))
+	}
+}
@@ -0,0 +1,70 @@
+package qb
+
+import "strings"
+
+// Savepoint returns a query that resolves to `SAVEPOINT "name"`, marking a
+// point inside a transaction that can later be rolled back to without
+// abandoning the whole transaction.
+func Savepoint(name string) RawQuery {
+	return Raw("SAVEPOINT " + quoteIdent(name))
+}
+
+// ReleaseSavepoint returns a query that resolves to `RELEASE SAVEPOINT
+// "name"`, keeping the work done since the savepoint was created.
+func ReleaseSavepoint(name string) RawQuery {
+	return Raw("RELEASE SAVEPOINT " + quoteIdent(name))
+}
+
+// RollbackToSavepoint returns a query that resolves to `ROLLBACK TO SAVEPOINT
+// "name"`, discarding the work done since the savepoint was created.
+func RollbackToSavepoint(name string) RawQuery {
+	return Raw("ROLLBACK TO SAVEPOINT " + quoteIdent(name))
+}
+
+// PrepareTransaction returns a query that resolves to `PREPARE TRANSACTION
+// 'id'`, the first phase of a two-phase commit on Postgres. The transaction
+// identifier is quoted as a string literal since the statement doesn't accept
+// parameters.
+func PrepareTransaction(id string) RawQuery {
+	return Raw("PREPARE TRANSACTION " + quoteLiteral(id))
+}
+
+// CommitPrepared returns a query that resolves to `COMMIT PREPARED 'id'`,
+// committing a transaction prepared with PrepareTransaction.
+func CommitPrepared(id string) RawQuery {
+	return Raw("COMMIT PREPARED " + quoteLiteral(id))
+}
+
+// RollbackPrepared returns a query that resolves to `ROLLBACK PREPARED 'id'`,
+// aborting a transaction prepared with PrepareTransaction.
+func RollbackPrepared(id string) RawQuery {
+	return Raw("ROLLBACK PREPARED " + quoteLiteral(id))
+}
+
+// SetConstraintsDeferred returns a query that resolves to `SET CONSTRAINTS
+// names DEFERRED`, postponing checks of the named deferrable constraints until
+// the end of the current transaction. With no names, every deferrable
+// constraint is deferred. This makes it possible to load rows with circular
+// foreign keys in any order.
+func SetConstraintsDeferred(names ...string) RawQuery {
+	return setConstraints(names, "DEFERRED")
+}
+
+// SetConstraintsImmediate returns a query that resolves to `SET CONSTRAINTS
+// names IMMEDIATE`, checking the named constraints at the end of each
+// statement again. With no names, it applies to every constraint.
+func SetConstraintsImmediate(names ...string) RawQuery {
+	return setConstraints(names, "IMMEDIATE")
+}
+
+func setConstraints(names []string, mode string) RawQuery {
+	target := "ALL"
+	if len(names) > 0 {
+		quoted := make([]string, 0, len(names))
+		for _, name := range names {
+			quoted = append(quoted, quoteIdent(name))
+		}
+		target = strings.Join(quoted, ", ")
+	}
+	return Raw("SET CONSTRAINTS " + target + " " + mode)
+}
@@ -0,0 +1,71 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestTransactionStatements(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name:  "savepoint",
+			query: qb.Savepoint("sp_1"),
+			want: output{
+				query: `SAVEPOINT "sp_1"`,
+			},
+		},
+		testcase{
+			name:  "release savepoint",
+			query: qb.ReleaseSavepoint("sp_1"),
+			want: output{
+				query: `RELEASE SAVEPOINT "sp_1"`,
+			},
+		},
+		testcase{
+			name:  "rollback to savepoint",
+			query: qb.RollbackToSavepoint("sp_1"),
+			want: output{
+				query: `ROLLBACK TO SAVEPOINT "sp_1"`,
+			},
+		},
+		testcase{
+			name:  "prepare transaction",
+			query: qb.PrepareTransaction("order-42"),
+			want: output{
+				query: `PREPARE TRANSACTION 'order-42'`,
+			},
+		},
+		testcase{
+			name:  "commit prepared",
+			query: qb.CommitPrepared("order-42"),
+			want: output{
+				query: `COMMIT PREPARED 'order-42'`,
+			},
+		},
+		testcase{
+			name:  "rollback prepared with quote",
+			query: qb.RollbackPrepared("o'42"),
+			want: output{
+				query: `ROLLBACK PREPARED 'o''42'`,
+			},
+		},
+		testcase{
+			name:  "defer all constraints",
+			query: qb.SetConstraintsDeferred(),
+			want: output{
+				query: `SET CONSTRAINTS ALL DEFERRED`,
+			},
+		},
+		testcase{
+			name:  "named constraints immediate",
+			query: qb.SetConstraintsImmediate("vehicles_dealership_fk", "dealerships_manager_fk"),
+			want: output{
+				query: `SET CONSTRAINTS "vehicles_dealership_fk", "dealerships_manager_fk" IMMEDIATE`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
@@ -0,0 +1,69 @@
+package qb
+
+// EqualT is like Equal, but the type of the value is part of the signature, so
+// instantiating it explicitly e.g. EqualT[int]("cost", v) turns binding the
+// wrong type into a compile error.
+func EqualT[T comparable](field string, v T) ComparisonClause {
+	return Equal(field, v)
+}
+
+// ColumnOf returns a handle for a column whose values are of type T. Declaring
+// the columns of a table once e.g.
+//
+//	var cost = qb.ColumnOf[int]("cost")
+//
+// and building comparisons from the handle means every value bound against the
+// column is checked by the compiler.
+func ColumnOf[T any](name string) TypedColumn[T] {
+	return TypedColumn[T]{Name: name}
+}
+
+// TypedColumn is a column whose values are of type T. See ColumnOf.
+type TypedColumn[T any] struct {
+	Name string
+}
+
+// Col returns a reference to the column that can be used as a comparison value
+// or selected with Expr.
+func (c TypedColumn[T]) Col() Column {
+	return Col(c.Name)
+}
+
+// Equal returns a boolean clause that resolves to the form `(column = value)`.
+func (c TypedColumn[T]) Equal(v T) ComparisonClause {
+	return Equal(c.Name, v)
+}
+
+// Greater returns a boolean clause that resolves to the form `(column > value)`.
+func (c TypedColumn[T]) Greater(v T) ComparisonClause {
+	return Greater(c.Name, v)
+}
+
+// GreaterEqual returns a boolean clause that resolves to the form `(column >=
+// value)`.
+func (c TypedColumn[T]) GreaterEqual(v T) ComparisonClause {
+	return GreaterEqual(c.Name, v)
+}
+
+// Less returns a boolean clause that resolves to the form `(column < value)`.
+func (c TypedColumn[T]) Less(v T) ComparisonClause {
+	return Less(c.Name, v)
+}
+
+// LessEqual returns a boolean clause that resolves to the form `(column <=
+// value)`.
+func (c TypedColumn[T]) LessEqual(v T) ComparisonClause {
+	return LessEqual(c.Name, v)
+}
+
+// EqualCol returns a boolean clause comparing the column to another column of
+// the same type e.g. in a join or a correlated subquery.
+func (c TypedColumn[T]) EqualCol(other TypedColumn[T]) ComparisonClause {
+	return Equal(c.Name, other.Col())
+}
+
+// Any returns a clause that resolves to the form `column = ANY(?)` with the
+// values bound as a single array parameter.
+func (c TypedColumn[T]) Any(values []T) AnyClause {
+	return Any(c.Name, values)
+}
@@ -0,0 +1,45 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestTypedColumns(t *testing.T) {
+	var (
+		cost     = qb.ColumnOf[int]("vehicles.cost")
+		make_    = qb.ColumnOf[string]("make")
+		ownerID  = qb.ColumnOf[int64]("vehicles.owner_id")
+		personID = qb.ColumnOf[int64]("people.id")
+	)
+
+	testcases := []testcase{
+		testcase{
+			name:  "equal",
+			query: qb.EqualT[string]("make", "Honda"),
+			want: output{
+				query: `make = ?`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name:  "column",
+			query: qb.And(make_.Equal("Honda"), cost.LessEqual(5000)),
+			want: output{
+				query: `(make = ? AND vehicles.cost <= ?)`,
+				vals:  []interface{}{"Honda", 5000},
+			},
+		},
+		testcase{
+			name:  "column to column",
+			query: ownerID.EqualCol(personID),
+			want: output{
+				query: `vehicles.owner_id = people.id`,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
@@ -0,0 +1,94 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Update returns a query that resolves to the general form `UPDATE table SET
+// column = ? [WHERE expr]`.
+func Update(table string) UpdateQuery {
+	return UpdateQuery{
+		Table: table,
+	}
+}
+
+// UpdateQuery represents a query that resolves to the general form `UPDATE
+// table SET column = ?, ... [WHERE expr]`.
+type UpdateQuery struct {
+	Table string
+
+	// Assignments holds the columns to set in order. An assignment renders
+	// exactly like an equality comparison, so each one is stored as one, which
+	// means subqueries, columns and Values can all be assigned.
+	Assignments []ComparisonClause
+
+	Vals        []interface{}
+	WhereClause Query
+}
+
+// Build returns a query string of the form `UPDATE table SET column = ?, ...
+// [WHERE expr]`.
+func (q UpdateQuery) Build() string {
+	sets := make([]string, 0, len(q.Assignments))
+	for _, a := range q.Assignments {
+		sets = append(sets, a.Build())
+	}
+	stmt := fmt.Sprintf("UPDATE %s SET %s", q.Table, strings.Join(sets, ", "))
+	if q.WhereClause != nil {
+		stmt += fmt.Sprintf(" WHERE %s", q.WhereClause.Build())
+	}
+	return stmt
+}
+
+func (q UpdateQuery) String() string {
+	return Dump(q)
+}
+
+// Values returns the values of the assignments followed by the accumulated
+// values for the WHERE clause.
+func (q UpdateQuery) Values() []interface{} {
+	var vals []interface{}
+	for _, a := range q.Assignments {
+		vals = append(vals, a.Values()...)
+	}
+	return append(vals, q.Vals...)
+}
+
+// ToSql is like SelectQuery.ToSql.
+func (q UpdateQuery) ToSql() (string, []interface{}, error) {
+	return toSQL(q)
+}
+
+// BuildFor is like SelectQuery.BuildFor.
+func (q UpdateQuery) BuildFor(d Dialect) string {
+	return BuildFor(q, d)
+}
+
+// Kind returns KindUpdate.
+func (q UpdateQuery) Kind() Kind {
+	return KindUpdate
+}
+
+// Set adds an assignment of the form `column = value` to the query. Like the
+// value of a comparison, a Query value is injected into the query string rather
+// than bound.
+func (q UpdateQuery) Set(column string, value interface{}) UpdateQuery {
+	q.Assignments = append(q.Assignments[:len(q.Assignments):len(q.Assignments)], Equal(column, value))
+	return q
+}
+
+// Where adds an additional WHERE clause condition to the query that will be
+// evaluated and injected into the final query string. Calling Where again
+// combines the conditions with AND.
+func (q UpdateQuery) Where(wq Query) UpdateQuery {
+	q.WhereClause, q.Vals = andWhere(q.WhereClause, q.Vals, wq)
+	return q
+}
+
+// OrWhere is like Where, but combines the condition with any existing WHERE
+// clause using OR.
+func (q UpdateQuery) OrWhere(wq Query) UpdateQuery {
+	q.WhereClause, q.Vals = orWhere(q.WhereClause, q.Vals, wq)
+	return q
+}
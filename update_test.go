@@ -0,0 +1,48 @@
+package qb_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestUpdateQuery(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name:  "set and where",
+			query: qb.Update("vehicles").Set("cost", 100).Set("sold", true).Where(qb.Equal("id", 5)),
+			want: output{
+				query: `UPDATE vehicles SET cost = ?, sold = ? WHERE id = ?`,
+				vals:  []interface{}{100, true, 5},
+			},
+		},
+		testcase{
+			name: "compound where",
+			query: qb.Update("vehicles").Set("sold", true).
+				Where(qb.And(qb.Equal("make", "Honda"), qb.Less("cost", 5000))),
+			want: output{
+				query: `UPDATE vehicles SET sold = ? WHERE (make = ? AND cost < ?)`,
+				vals:  []interface{}{true, "Honda", 5000},
+			},
+		},
+		testcase{
+			name: "expressions",
+			query: qb.Update("vehicles").
+				Set("list_price", qb.Col("cost")).
+				Set("owner_id", qb.Select("people", "id").Where(qb.Equal("name", "Ann"))).
+				Filter(qb.NewFilter("unsold", qb.Equal("sold", false))),
+			want: output{
+				query: `UPDATE vehicles SET list_price = cost, owner_id = (SELECT id FROM people WHERE name = ?) WHERE sold = ?`,
+				vals:  []interface{}{"Ann", false},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+
+	if err := qb.Verify(qb.Update("vehicles").Where(qb.Equal("id", 5))); !errors.Is(err, qb.ErrNoAssignments) {
+		t.Errorf("wanted ErrNoAssignments, got %v", err)
+	}
+}
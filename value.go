@@ -0,0 +1,242 @@
+package qb
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ValueKind identifies the type of data held by a Value.
+type ValueKind string
+
+// The kinds of Value.
+const (
+	ValueNull     ValueKind = "null"
+	ValueInt      ValueKind = "int"
+	ValueFloat    ValueKind = "float"
+	ValueBool     ValueKind = "bool"
+	ValueString   ValueKind = "string"
+	ValueTime     ValueKind = "time"
+	ValueBytes    ValueKind = "bytes"
+	ValueExpr     ValueKind = "expr"
+	ValueSubquery ValueKind = "subquery"
+)
+
+// Value is a typed alternative to the interface{} accepted by clause
+// constructors such as Equal. Where a bare interface{} leaves it to the type
+// switch at build time to decide whether something is bound, inlined or
+// wrapped in parentheses, a Value records that decision when it is created,
+// and keeps enough type information to survive a round trip through JSON
+// intact. Values can be passed anywhere a comparison value is accepted e.g.
+//
+//	qb.Equal("created_at", qb.TimeValue(t))
+//	qb.Equal("id", qb.ExprValue(qb.NextVal("ids")))
+type Value struct {
+	kind ValueKind
+	v    interface{}
+}
+
+// NullValue returns a Value that is bound as NULL.
+func NullValue() Value {
+	return Value{kind: ValueNull}
+}
+
+// IntValue returns a Value that is bound as an integer.
+func IntValue(n int64) Value {
+	return Value{kind: ValueInt, v: n}
+}
+
+// FloatValue returns a Value that is bound as a floating point number.
+func FloatValue(f float64) Value {
+	return Value{kind: ValueFloat, v: f}
+}
+
+// BoolValue returns a Value that is bound as a boolean.
+func BoolValue(b bool) Value {
+	return Value{kind: ValueBool, v: b}
+}
+
+// StringValue returns a Value that is bound as a string.
+func StringValue(s string) Value {
+	return Value{kind: ValueString, v: s}
+}
+
+// TimeValue returns a Value that is bound as a timestamp.
+func TimeValue(t time.Time) Value {
+	return Value{kind: ValueTime, v: t}
+}
+
+// BytesValue returns a Value that is bound as a byte string.
+func BytesValue(b []byte) Value {
+	return Value{kind: ValueBytes, v: b}
+}
+
+// ExprValue returns a Value that renders the query inline, without the
+// parentheses that a subquery would get, e.g. a function call or a column.
+func ExprValue(q Query) Value {
+	return Value{kind: ValueExpr, v: q}
+}
+
+// SubqueryValue returns a Value that renders the query in parentheses.
+func SubqueryValue(q Query) Value {
+	return Value{kind: ValueSubquery, v: q}
+}
+
+// Kind returns the kind of data held by the Value.
+func (v Value) Kind() ValueKind {
+	if v.kind == "" {
+		return ValueNull
+	}
+	return v.kind
+}
+
+// Interface returns the underlying data. For ValueExpr and ValueSubquery this
+// is the Query.
+func (v Value) Interface() interface{} {
+	return v.v
+}
+
+func (v Value) withDialect(d Dialect) interface{} {
+	if q, ok := v.v.(Query); ok {
+		v.v = forDialect(q, d)
+	}
+	return v
+}
+
+// Value implements driver.Valuer so that a Value can be passed directly as a
+// query argument. Expressions and subqueries can't be bound and return an
+// error.
+func (v Value) Value() (driver.Value, error) {
+	switch v.Kind() {
+	case ValueExpr, ValueSubquery:
+		return nil, fmt.Errorf("qb: %s value can't be bound as a parameter", v.kind)
+	}
+	return v.v, nil
+}
+
+func (v Value) String() string {
+	switch v.Kind() {
+	case ValueNull:
+		return "null"
+	case ValueExpr, ValueSubquery:
+		if q, ok := v.v.(Query); ok {
+			return fmt.Sprintf("%s(%s)", v.kind, q.Build())
+		}
+		return fmt.Sprintf("%s(<nil>)", v.kind)
+	case ValueTime:
+		return fmt.Sprintf("time(%s)", v.v.(time.Time).Format(time.RFC3339Nano))
+	}
+	return fmt.Sprintf("%s(%s)", v.kind, dumpValue(v.v))
+}
+
+// resolve returns the form of the value that the clause builders understand: a
+// scalar query for expressions, a query for subqueries and the raw data for
+// everything else.
+func (v Value) resolve() interface{} {
+	switch v.Kind() {
+	case ValueExpr:
+		q, _ := v.v.(Query)
+		if _, ok := q.(scalar); ok || q == nil {
+			return q
+		}
+		return inlineExpr{q}
+	case ValueSubquery:
+		q, _ := v.v.(Query)
+		if e, ok := q.(inlineExpr); ok {
+			return e.Query
+		}
+		return q
+	}
+	return v.v
+}
+
+// valueOf unwraps a Value, leaving any other comparison value untouched.
+func valueOf(v interface{}) interface{} {
+	if val, ok := v.(Value); ok {
+		return val.resolve()
+	}
+	return v
+}
+
+// inlineExpr marks an arbitrary query as scalar so that it is rendered without
+// parentheses.
+type inlineExpr struct {
+	Query
+}
+
+func (e inlineExpr) scalar() {}
+
+// MarshalJSON encodes the Value with its kind so that times and byte strings
+// decode to the same types, which isn't possible for a bare interface{}.
+func (v Value) MarshalJSON() ([]byte, error) {
+	data := v.v
+	if t, ok := data.(time.Time); ok {
+		data = t.Format(time.RFC3339Nano)
+	}
+	return json.Marshal(struct {
+		Type string      `json:"type"`
+		Kind ValueKind   `json:"Kind"`
+		Data interface{} `json:"Data,omitempty"`
+	}{"Value", v.Kind(), data})
+}
+
+func (v *Value) UnmarshalJSON(b []byte) error {
+	return decoder{}.valueOf(b, v)
+}
+
+func (d decoder) valueOf(b []byte, v *Value) error {
+	var fields struct {
+		Type string
+		Kind ValueKind
+		Data json.RawMessage
+	}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return fmt.Errorf("qb: decode Value: %w", err)
+	}
+	if fields.Type != "Value" {
+		return fmt.Errorf("qb: decode Value: unexpected type %s", fields.Type)
+	}
+
+	*v = Value{kind: fields.Kind}
+	var err error
+	switch fields.Kind {
+	case ValueNull:
+		return nil
+	case ValueInt:
+		var n int64
+		err = json.Unmarshal(fields.Data, &n)
+		v.v = n
+	case ValueFloat:
+		var f float64
+		err = json.Unmarshal(fields.Data, &f)
+		v.v = f
+	case ValueBool:
+		var b bool
+		err = json.Unmarshal(fields.Data, &b)
+		v.v = b
+	case ValueString:
+		var s string
+		err = json.Unmarshal(fields.Data, &s)
+		v.v = s
+	case ValueTime:
+		var s string
+		if err = json.Unmarshal(fields.Data, &s); err == nil {
+			v.v, err = time.Parse(time.RFC3339Nano, s)
+		}
+	case ValueBytes:
+		var b []byte
+		err = json.Unmarshal(fields.Data, &b)
+		v.v = b
+	case ValueExpr, ValueSubquery:
+		if len(fields.Data) > 0 {
+			v.v, err = d.query(fields.Data)
+		}
+	default:
+		return fmt.Errorf("qb: decode Value: unknown kind %q", fields.Kind)
+	}
+	if err != nil {
+		return fmt.Errorf("qb: decode Value: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package qb_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestValue(t *testing.T) {
+	ts := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	testcases := []testcase{
+		testcase{
+			name:  "bound",
+			query: qb.And(qb.Equal("id", qb.IntValue(5)), qb.Greater("created_at", qb.TimeValue(ts))),
+			want: output{
+				query: `(id = ? AND created_at > ?)`,
+				vals:  []interface{}{int64(5), ts},
+			},
+		},
+		testcase{
+			name:  "expr",
+			query: qb.Equal("id", qb.ExprValue(qb.Raw("lower(?)", "X"))),
+			want: output{
+				query: `id = lower(?)`,
+				vals:  []interface{}{"X"},
+			},
+		},
+		testcase{
+			name:  "subquery",
+			query: qb.Equal("id", qb.SubqueryValue(qb.Select("vehicles", "id").Where(qb.Equal("make", "Honda")))),
+			want: output{
+				query: `id = (SELECT id FROM vehicles WHERE make = ?)`,
+				vals:  []interface{}{"Honda"},
+			},
+		},
+		testcase{
+			name:  "null",
+			query: qb.Equal("deleted_at", qb.NullValue()),
+			want: output{
+				query: `deleted_at = ?`,
+				vals:  []interface{}{nil},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
+
+func TestValueJSON(t *testing.T) {
+	ts := time.Date(2021, 3, 4, 5, 6, 7, 8, time.UTC)
+	q := qb.And(
+		qb.Equal("created_at", qb.TimeValue(ts)),
+		qb.Equal("hash", qb.BytesValue([]byte{1, 2, 3})),
+	)
+
+	b, err := json.Marshal(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := qb.UnmarshalQuery(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Values(), q.Values()) {
+		t.Errorf("\n\twanted:\n%#v\n\tgot:\n%#v", q.Values(), got.Values())
+	}
+}
+
+func TestValueVerify(t *testing.T) {
+	err := qb.Verify(qb.Equal("id", qb.SubqueryValue(nil)))
+	if !errors.Is(err, qb.ErrMissingQuery) {
+		t.Errorf("wanted ErrMissingQuery, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValuesTable returns a relation that resolves to the form `(VALUES (?, ?),
+// (?, ?)) AS alias(col1, col2)`, turning in-memory rows into a table that can be
+// selected from or joined against. There must be at least one row, and each
+// row must have one value per column, which Verify checks.
+func ValuesTable(alias string, columns []string, rows [][]interface{}) ValuesTableClause {
+	return ValuesTableClause{
+		Alias:   alias,
+		Columns: columns,
+		Rows:    rows,
+	}
+}
+
+// ValuesTableClause represents a VALUES list used as a relation.
+type ValuesTableClause struct {
+	Alias   string
+	Columns []string
+	Rows    [][]interface{}
+}
+
+// Build returns a relation of the form `(VALUES (?, ?), (?, ?)) AS alias(col1,
+// col2)`.
+func (c ValuesTableClause) Build() string {
+	rows := make([]string, 0, len(c.Rows))
+	for _, row := range c.Rows {
+		rows = append(rows, "("+placeholders(len(row))+")")
+	}
+	return fmt.Sprintf("(VALUES %s) AS %s(%s)", strings.Join(rows, ", "), c.Alias, strings.Join(c.Columns, ", "))
+}
+
+func (c ValuesTableClause) String() string {
+	return c.Build()
+}
+
+// Values returns the values of every row in order.
+func (c ValuesTableClause) Values() []interface{} {
+	var vals []interface{}
+	for _, row := range c.Rows {
+		vals = append(vals, row...)
+	}
+	return vals
+}
+
+func (c ValuesTableClause) name() string {
+	return c.Alias
+}
+
+// relation is implemented by queries that can be used in a FROM clause under
+// a name of their own.
+type relation interface {
+	Query
+	name() string
+}
+
+// SelectFrom returns a query that resolves to the general form `SELECT fields
+// FROM relation [WHERE expr]`, where the relation is something other than a
+// plain table, such as a ValuesTable. The relation's alias is used as the
+// table name when the query is joined.
+func SelectFrom(rel Query, fields ...string) SelectQuery {
+	q := Select("", fields...)
+	if r, ok := rel.(relation); ok {
+		q.Table = r.name()
+	}
+	q.Source = rel
+	return q
+}
@@ -0,0 +1,60 @@
+package qb_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestValuesTable(t *testing.T) {
+	prices := qb.ValuesTable("v", []string{"id", "price"}, [][]interface{}{
+		{1, 100},
+		{2, 200},
+	})
+
+	testcases := []testcase{
+		testcase{
+			name:  "select from values",
+			query: qb.SelectFrom(prices, "id").Where(qb.Greater("price", 150)),
+			want: output{
+				query: `SELECT id FROM (VALUES (?, ?), (?, ?)) AS v(id, price) WHERE price > ?`,
+				vals:  []interface{}{1, 100, 2, 200, 150},
+			},
+		},
+		testcase{
+			name: "join against values",
+			query: qb.Join(
+				qb.Select("vehicles", "id").Where(qb.Equal("make", "Honda")),
+				qb.SelectFrom(prices, "price"),
+			).On("vehicles.id", "v.id"),
+			want: output{
+				query: `SELECT vehicles.id, v.price FROM vehicles, (VALUES (?, ?), (?, ?)) AS v(id, price) WHERE vehicles.id = v.id AND (make = ?)`,
+				vals:  []interface{}{1, 100, 2, 200, "Honda"},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
+
+func TestValuesTableVerify(t *testing.T) {
+	testcases := []struct {
+		name string
+		rows [][]interface{}
+		kind error
+	}{
+		{"no rows", nil, qb.ErrNoRows},
+		{"empty row", [][]interface{}{{}}, qb.ErrRowLength},
+		{"ragged rows", [][]interface{}{{1, 100}, {2}}, qb.ErrRowLength},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := qb.SelectFrom(qb.ValuesTable("v", []string{"id", "price"}, tc.rows), "id")
+			if err := qb.Verify(q); !errors.Is(err, tc.kind) {
+				t.Errorf("wanted %v, got %v", tc.kind, err)
+			}
+		})
+	}
+}
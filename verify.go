@@ -0,0 +1,402 @@
+package qb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors reported by Verify. They are always wrapped in an *Error that
+// identifies where in the query tree the problem was found.
+var (
+	ErrMissingQuery        = errors.New("missing query")
+	ErrEmptyIdentifier     = errors.New("empty identifier")
+	ErrPlaceholderMismatch = errors.New("placeholder count doesn't match value count")
+	ErrNoAssignments       = errors.New("no columns to set")
+	ErrNoRows              = errors.New("no rows")
+	ErrRowLength           = errors.New("row length doesn't match column count")
+)
+
+// Error is returned by Verify when a query is invalid. Path describes the
+// location of the invalid clause from the root of the query down, e.g.
+// `where > and[1] > comparison("cost")`, so that the problem can be found
+// without dumping the entire tree. Use errors.As to retrieve it.
+type Error struct {
+	Path []string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if len(e.Path) == 0 {
+		return "qb: " + e.Err.Error()
+	}
+	return fmt.Sprintf("qb: %s: %s", strings.Join(e.Path, " > "), e.Err)
+}
+
+// Unwrap returns the underlying error so that errors.Is can be used to check
+// for the kind of problem.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Verify checks that a query is well formed before it is executed. It reports
+// missing subqueries, empty identifiers, updates that don't set anything, and
+// a mismatch between the number of placeholders in the query string and the
+// number of values. Verify is most useful for queries that are assembled
+// dynamically at runtime, where mistakes can't be caught by tests of fixed
+// queries. Errors are always of type *Error.
+func Verify(q Query) error {
+	if err := verify(q, nil); err != nil {
+		return err
+	}
+	sql := q.Build()
+	if n, vals := countPlaceholders(sql), len(q.Values()); n != vals {
+		return &Error{
+			Err: fmt.Errorf("%w: %d placeholder(s) but %d value(s) in %s", ErrPlaceholderMismatch, n, vals, sql),
+		}
+	}
+	return nil
+}
+
+// at returns a copy of path with the segment appended so that sibling clauses
+// never share a backing array.
+func at(path []string, segment string) []string {
+	return append(path[:len(path):len(path)], segment)
+}
+
+func verify(q Query, path []string) error {
+	if q == nil {
+		return &Error{Path: path, Err: ErrMissingQuery}
+	}
+
+	switch q := q.(type) {
+	case InClause:
+		return verifyIdent(path, "IN field", q.Field)
+	case AnyClause:
+		return verifyIdent(path, "ANY field", q.Field)
+	case NullClause:
+		return verifyIdent(path, "IS NULL field", q.Field)
+	case BetweenClause:
+		path = at(path, fmt.Sprintf("between(%q)", q.Field))
+		if err := verifyIdent(path, "field", q.Field); err != nil {
+			return err
+		}
+		for _, bound := range []interface{}{q.Low, q.High} {
+			if sub, ok := valueOf(bound).(Query); ok {
+				if err := verify(sub, path); err != nil {
+					return err
+				}
+			}
+		}
+	case ComparisonClause:
+		path = at(path, fmt.Sprintf("comparison(%q)", q.Field))
+		if err := verifyIdent(path, "field", q.Field); err != nil {
+			return err
+		}
+		if v, ok := q.Value.(Value); ok && (v.Kind() == ValueExpr || v.Kind() == ValueSubquery) {
+			sub, _ := v.Interface().(Query)
+			return verify(sub, at(path, "value"))
+		}
+		if sub, ok := q.Value.(Query); ok {
+			return verify(sub, at(path, "value"))
+		}
+	case Column:
+		return verifyIdent(path, "column", string(q))
+	case AliasClause:
+		path = at(path, fmt.Sprintf("as(%q)", q.Alias))
+		if err := verifyIdent(path, "alias", q.Alias); err != nil {
+			return err
+		}
+		return verify(q.Query, path)
+	case BooleanQuery:
+		op := strings.ToLower(q.Op)
+		if err := verify(q.Comparison1, at(path, op+"[0]")); err != nil {
+			return err
+		}
+		return verify(q.Comparison2, at(path, op+"[1]"))
+	case NotClause:
+		return verify(q.Query, at(path, "not"))
+	case Filter:
+		return verify(q.Cond, at(path, fmt.Sprintf("filter(%q)", q.Name)))
+	case DeleteQuery:
+		if err := verifyIdent(path, "table", q.Table); err != nil {
+			return err
+		}
+		if err := verifyOptional(q.WhereClause, at(path, "where")); err != nil {
+			return err
+		}
+		return verify(q.Ordering, at(path, "order by"))
+	case UpdateQuery:
+		if err := verifyIdent(path, "table", q.Table); err != nil {
+			return err
+		}
+		if len(q.Assignments) == 0 {
+			return &Error{Path: at(path, "set"), Err: ErrNoAssignments}
+		}
+		for i, a := range q.Assignments {
+			if err := verify(a, at(path, fmt.Sprintf("set[%d]", i))); err != nil {
+				return err
+			}
+		}
+		return verifyOptional(q.WhereClause, at(path, "where"))
+	case SelectQuery:
+		return verifySelect(q, path)
+	case On:
+		path = at(path, "on")
+		if err := verifyIdent(path, "field", q.Field1); err != nil {
+			return err
+		}
+		return verifyIdent(path, "field", q.Field2)
+	case JoinQuery:
+		if err := verifySelect(q.Query1, at(path, "join[0]")); err != nil {
+			return err
+		}
+		if err := verifySelect(q.Query2, at(path, "join[1]")); err != nil {
+			return err
+		}
+		if q.OnClause == nil {
+			return &Error{Path: at(path, "on"), Err: ErrMissingQuery}
+		}
+		if err := verify(q.OnClause, path); err != nil {
+			return err
+		}
+		for i, j := range q.Joins {
+			jpath := at(path, fmt.Sprintf("join[%d]", i+2))
+			if err := verifySelect(j.Query, jpath); err != nil {
+				return err
+			}
+			if err := verifyIdent(jpath, "type", j.JoinType); err != nil {
+				return err
+			}
+			if j.OnClause == nil {
+				return &Error{Path: at(jpath, "on"), Err: ErrMissingQuery}
+			}
+			if err := verify(j.OnClause, jpath); err != nil {
+				return err
+			}
+		}
+		if err := verifyOptional(q.WhereClause, at(path, "where")); err != nil {
+			return err
+		}
+	case Order:
+		if q.Expr != nil {
+			return verify(q.Expr, path)
+		}
+		return verifyIdent(path, "field", q.Field)
+	case OrderByClause:
+		for i, o := range q {
+			if err := verify(o, at(path, fmt.Sprintf("order[%d]", i))); err != nil {
+				return err
+			}
+		}
+	case TemplateQuery:
+		for name, hole := range q.Holes {
+			if err := verify(hole, at(path, fmt.Sprintf("hole(%q)", name))); err != nil {
+				return err
+			}
+		}
+		if q.WhereClause != nil {
+			if err := verify(q.WhereClause, at(path, `hole("where")`)); err != nil {
+				return err
+			}
+		}
+		for i, o := range q.Ordering {
+			if err := verify(o, at(path, fmt.Sprintf(`hole("order")[%d]`, i))); err != nil {
+				return err
+			}
+		}
+	case JSONObjectClause:
+		for i, key := range q.Keys {
+			if err := verify(q.Exprs[i], at(path, fmt.Sprintf("key(%q)", key))); err != nil {
+				return err
+			}
+		}
+	case JSONPathClause:
+		return verifyIdent(at(path, q.Func), "field", q.Field)
+	case XMLClause:
+		return verifyIdent(at(path, q.Func), "field", q.Field)
+	case DateArithExpr:
+		return verifyIdent(path, "field", q.Field)
+	case TimeZoneExpr:
+		return verifyIdent(path, "field", q.Field)
+	case DateTruncExpr:
+		return verifyIdent(path, "field", q.Field)
+	case TimeBucketExpr:
+		return verifyIdent(path, "field", q.Field)
+	case WidthBucketExpr:
+		return verifyIdent(path, "field", q.Field)
+	case PercentileClause:
+		return verifyIdent(path, "field", q.Field)
+	case MedianExpr:
+		return verifyIdent(path, "field", q.Field)
+	case ValuesTableClause:
+		path = at(path, fmt.Sprintf("values(%q)", q.Alias))
+		if err := verifyIdent(path, "alias", q.Alias); err != nil {
+			return err
+		}
+		for _, column := range q.Columns {
+			if err := verifyIdent(path, "column", column); err != nil {
+				return err
+			}
+		}
+		if len(q.Rows) == 0 {
+			return &Error{Path: path, Err: ErrNoRows}
+		}
+		for i, row := range q.Rows {
+			if len(row) != len(q.Columns) {
+				return &Error{
+					Path: at(path, fmt.Sprintf("row[%d]", i)),
+					Err:  fmt.Errorf("%w: %d value(s) for %d column(s)", ErrRowLength, len(row), len(q.Columns)),
+				}
+			}
+		}
+	case MaskExpr:
+		return verifyIdent(path, "column", q.Column)
+	case RowToJSONExpr:
+		return verifyIdent(path, "relation", q.Rel)
+	case HashExpr:
+		if len(q.Columns) == 0 {
+			return verifyIdent(path, "table", q.Table)
+		}
+		for _, column := range q.Columns {
+			if err := verifyIdent(path, "column", column); err != nil {
+				return err
+			}
+		}
+	case TableFuncClause:
+		return verifyIdent(path, "alias", q.Alias)
+	case CopyQuery:
+		return verify(q.Query, at(path, "copy"))
+	case WindowClause:
+		if err := verify(q.Func, at(path, "over")); err != nil {
+			return err
+		}
+		return verify(q.Ordering, at(path, "order by"))
+	case AggregateClause:
+		path = at(path, q.Func)
+		if q.Expr != nil {
+			if err := verify(q.Expr, path); err != nil {
+				return err
+			}
+		} else if err := verifyIdent(path, "field", q.Field); err != nil {
+			return err
+		}
+		return verify(q.Ordering, at(path, "order by"))
+	case CreateTableAsQuery:
+		if err := verifyIdent(path, "table", q.Name); err != nil {
+			return err
+		}
+		return verifySelect(q.Query, at(path, "as"))
+	case ExplainQuery:
+		return verify(q.Query, path)
+	case AnnotatedQuery:
+		return verify(q.Query, path)
+	case AuditQuery:
+		if q.Query == nil {
+			return &Error{Path: at(path, "audit"), Err: ErrMissingQuery}
+		}
+		if err := verifyIdent(path, "history table", q.HistoryTable); err != nil {
+			return err
+		}
+		return verify(q.Query, path)
+	case CaptureQuery:
+		if q.Query == nil {
+			return &Error{Path: at(path, "capture"), Err: ErrMissingQuery}
+		}
+		for _, col := range q.PrimaryKey {
+			if err := verifyIdent(path, "primary key", col); err != nil {
+				return err
+			}
+		}
+		return verify(q.Query, path)
+	}
+	return nil
+}
+
+func verifySelect(q SelectQuery, path []string) error {
+	if q.Source != nil {
+		if err := verify(q.Source, at(path, "from")); err != nil {
+			return err
+		}
+	} else if err := verifyIdent(path, "table", q.Table); err != nil {
+		return err
+	}
+	for i, field := range q.Fields {
+		if err := verifyIdent(at(path, fmt.Sprintf("field[%d]", i)), "field", field); err != nil {
+			return err
+		}
+	}
+	for i, expr := range q.Exprs {
+		if err := verify(expr, at(path, fmt.Sprintf("expr[%d]", i))); err != nil {
+			return err
+		}
+	}
+	if err := verifyOptional(q.WhereClause, at(path, "where")); err != nil {
+		return err
+	}
+	for i, field := range q.Groups {
+		if err := verifyIdent(at(path, fmt.Sprintf("group[%d]", i)), "field", field); err != nil {
+			return err
+		}
+	}
+	if err := verifyOptional(q.HavingClause, at(path, "having")); err != nil {
+		return err
+	}
+	return verify(q.Ordering, at(path, "order by"))
+}
+
+func verifyOptional(q Query, path []string) error {
+	if q == nil {
+		return nil
+	}
+	return verify(q, path)
+}
+
+func verifyIdent(path []string, kind, name string) error {
+	if name == "" {
+		return &Error{
+			Path: path,
+			Err:  fmt.Errorf("%w: %s", ErrEmptyIdentifier, kind),
+		}
+	}
+	return nil
+}
+
+// countPlaceholders returns the number of `?` placeholders in sql, ignoring any
+// that appear inside quoted strings, quoted identifiers or comments.
+func countPlaceholders(sql string) int {
+	n := 0
+	scanPlaceholders(sql, func(int) { n++ })
+	return n
+}
+
+// scanPlaceholders calls fn with the offset of every `?` in sql that isn't part
+// of a quoted string, quoted identifier or comment.
+func scanPlaceholders(sql string, fn func(offset int)) {
+	for i := 0; i < len(sql); i++ {
+		switch c := sql[i]; {
+		case c == '?':
+			fn(i)
+		case c == '\'' || c == '"':
+			// Doubled quotes are escapes, which this handles naturally by
+			// closing and immediately reopening the quoted section.
+			for i++; i < len(sql) && sql[i] != c; i++ {
+			}
+		case c == '-' && i+1 < len(sql) && sql[i+1] == '-':
+			for i++; i < len(sql) && sql[i] != '\n'; i++ {
+			}
+		case c == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			for i += 2; i+1 < len(sql) && !(sql[i] == '*' && sql[i+1] == '/'); i++ {
+			}
+			i++
+		}
+	}
+}
+
+// toSQL implements ToSql for every statement builder.
+func toSQL(q Query) (string, []interface{}, error) {
+	if err := Verify(q); err != nil {
+		return "", nil, err
+	}
+	return q.Build(), q.Values(), nil
+}
@@ -0,0 +1,137 @@
+package qb_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestVerify(t *testing.T) {
+	valid := []qb.Query{
+		qb.Select("vehicles", "id").Where(qb.And(qb.Equal("make", "Honda"), qb.Less("cost", 10))),
+		qb.Join(qb.Select("employees", "id"), qb.Select("dealerships", "name")).On("employees.dealership_id", "dealerships.id"),
+		qb.Raw("SELECT '?', \"?\" FROM t /* ? */ WHERE a = ? -- ?\nAND b = ?", 1, 2),
+		qb.Raw("SELECT 'it''s?' FROM t WHERE a = ?", 1),
+		qb.Annotate(qb.Delete("vehicles").Where(qb.Equal("id", 1)), "why?"),
+	}
+	for _, q := range valid {
+		if err := qb.Verify(q); err != nil {
+			t.Errorf("unexpected error for %s: %v", q.Build(), err)
+		}
+	}
+
+	invalid := map[string]qb.Query{
+		"nil query":             nil,
+		"missing boolean side":  qb.Or(qb.Equal("make", "Honda"), qb.And(qb.Equal("make", "Honda"), nil)),
+		"unbound join":          qb.Join(qb.Select("employees", "id"), qb.Select("dealerships", "name")),
+		"empty table":           qb.Select(""),
+		"empty field":           qb.Select("vehicles", "id", ""),
+		"empty comparison":      qb.Delete("vehicles").Where(qb.Equal("", 1)),
+		"empty alias":           qb.Select("vehicles").Expr(qb.As(qb.Col("id"), "")),
+		"empty nested field":    qb.Select("photos").Where(qb.Equal("vehicle_id", qb.Select("vehicles", ""))),
+		"too few values":        qb.Raw("SELECT * FROM vehicles WHERE make = ?"),
+		"too many values":       qb.Raw("SELECT * FROM vehicles", 1),
+		"empty ordering column": qb.Select("vehicles").Sort(qb.OrderByClause{{Dir: qb.Asc}}),
+	}
+	for name, q := range invalid {
+		if err := qb.Verify(q); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestVerifyErrorPath(t *testing.T) {
+	testcases := []struct {
+		name  string
+		query qb.Query
+		kind  error
+		want  string
+	}{
+		{
+			name: "nested comparison",
+			query: qb.Select("vehicles").Where(qb.And(
+				qb.Equal("make", "Honda"),
+				qb.Equal("", 10),
+			)),
+			kind: qb.ErrEmptyIdentifier,
+			want: `qb: where > and[1] > comparison(""): empty identifier: field`,
+		},
+		{
+			name: "subquery",
+			query: qb.Select("photos").Where(qb.Or(
+				qb.Equal("vehicle_id", qb.Select("vehicles", "id", "")),
+				qb.Equal("public", true),
+			)),
+			kind: qb.ErrEmptyIdentifier,
+			want: `qb: where > or[0] > comparison("vehicle_id") > value > field[1]: empty identifier: field`,
+		},
+		{
+			name:  "unbound join",
+			query: qb.Join(qb.Select("employees", "id"), qb.Select("dealerships", "name")),
+			kind:  qb.ErrMissingQuery,
+			want:  `qb: on: missing query`,
+		},
+		{
+			name:  "placeholder mismatch",
+			query: qb.Raw("SELECT * FROM vehicles WHERE make = ?"),
+			kind:  qb.ErrPlaceholderMismatch,
+			want:  `qb: placeholder count doesn't match value count: 1 placeholder(s) but 0 value(s) in SELECT * FROM vehicles WHERE make = ?`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := qb.Verify(tc.query)
+			var qbErr *qb.Error
+			if !errors.As(err, &qbErr) {
+				t.Fatalf("expected a *qb.Error, got %T: %v", err, err)
+			}
+			if !errors.Is(err, tc.kind) {
+				t.Errorf("expected error to wrap %v", tc.kind)
+			}
+			if got := err.Error(); got != tc.want {
+				t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", tc.want, got)
+			}
+		})
+	}
+}
+
+type sqlizer interface {
+	ToSql() (string, []interface{}, error)
+}
+
+func TestToSql(t *testing.T) {
+	builders := []sqlizer{
+		qb.Select("vehicles"),
+		qb.Delete("vehicles"),
+		qb.Join(qb.Select("a"), qb.Select("b")).On("a.id", "b.a_id"),
+		qb.Raw("SELECT 1"),
+		qb.Template("SELECT 1"),
+		qb.Explain(qb.Select("vehicles")),
+		qb.Annotate(qb.Select("vehicles"), "report"),
+		qb.Grant("SELECT").OnTable("vehicles").To("reporting"),
+		qb.CreateRole("reporting"),
+		qb.CreateSequence("vehicle_ids"),
+		qb.CreateTableAs("archive", qb.Select("vehicles")),
+	}
+	for _, b := range builders {
+		if _, _, err := b.ToSql(); err != nil {
+			t.Errorf("%T: unexpected error: %v", b, err)
+		}
+	}
+
+	sql, vals, err := qb.Select("vehicles", "id").Where(qb.Equal("make", "Honda")).ToSql()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `SELECT id FROM vehicles WHERE make = ?`; sql != want {
+		t.Errorf("\n\twanted:\n%s\n\tgot:\n%s", want, sql)
+	}
+	if len(vals) != 1 || vals[0] != "Honda" {
+		t.Errorf("unexpected values: %v", vals)
+	}
+
+	if _, _, err := qb.Raw("SELECT ?").ToSql(); !errors.Is(err, qb.ErrPlaceholderMismatch) {
+		t.Errorf("expected a placeholder mismatch, got %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Over returns a window expression that resolves to the form `fn OVER
+// (PARTITION BY fields ORDER BY terms)`. The function is usually a RawQuery
+// such as `Raw("row_number()")`.
+func Over(fn Query) WindowClause {
+	return WindowClause{
+		Func: fn,
+	}
+}
+
+// WindowClause represents a call to a window function.
+type WindowClause struct {
+	Func      Query
+	Partition []string
+	Ordering  OrderByClause
+}
+
+// PartitionBy adds fields to the PARTITION BY list of the window.
+func (c WindowClause) PartitionBy(fields ...string) WindowClause {
+	c.Partition = append(c.Partition[:len(c.Partition):len(c.Partition)], fields...)
+	return c
+}
+
+// Sort appends the terms of an ORDER BY clause to the ordering of the window.
+func (c WindowClause) Sort(o OrderByClause) WindowClause {
+	c.Ordering = append(c.Ordering[:len(c.Ordering):len(c.Ordering)], o...)
+	return c
+}
+
+// Build returns an expression of the form `fn OVER (PARTITION BY fields ORDER
+// BY terms)`. Either part of the window definition is omitted if it is empty.
+func (c WindowClause) Build() string {
+	var window []string
+	if len(c.Partition) > 0 {
+		window = append(window, "PARTITION BY "+strings.Join(c.Partition, ", "))
+	}
+	if len(c.Ordering) > 0 {
+		window = append(window, "ORDER BY "+c.Ordering.Build())
+	}
+	return fmt.Sprintf("%s OVER (%s)", c.Func.Build(), strings.Join(window, " "))
+}
+
+func (c WindowClause) String() string {
+	return c.Build()
+}
+
+// Values returns the values of the function followed by those of the ordering.
+func (c WindowClause) Values() []interface{} {
+	vals := c.Func.Values()
+	return append(vals[:len(vals):len(vals)], c.Ordering.Values()...)
+}
+
+func (c WindowClause) scalar() {}
+
+// StringAgg returns an aggregate expression that resolves to the form
+// `string_agg(field, ?)`, concatenating the values of a column with the given
+// separator.
+func StringAgg(field, sep string) AggregateClause {
+	return AggregateClause{
+		Func:  "string_agg",
+		Field: field,
+		Args:  []interface{}{sep},
+	}
+}
+
+// AggregateClause represents a call to an aggregate function whose input is
+// ordered, such as string_agg or array_agg.
+type AggregateClause struct {
+	Func     string
+	Field    string
+	Args     []interface{}
+	Ordering OrderByClause
+
+	// Expr is an expression to aggregate. If it is set, it is used in place
+	// of Field.
+	Expr Query
+}
+
+// Sort appends the terms of an ORDER BY clause to the ordering of the
+// aggregate's input.
+func (c AggregateClause) Sort(o OrderByClause) AggregateClause {
+	c.Ordering = append(c.Ordering[:len(c.Ordering):len(c.Ordering)], o...)
+	return c
+}
+
+// Build returns an expression of the form `fn(field, ? ORDER BY terms)`.
+func (c AggregateClause) Build() string {
+	args := c.Field
+	if c.Expr != nil {
+		args = c.Expr.Build()
+	}
+	if len(c.Args) > 0 {
+		args += ", " + placeholders(len(c.Args))
+	}
+	if len(c.Ordering) > 0 {
+		args += " ORDER BY " + c.Ordering.Build()
+	}
+	return fmt.Sprintf("%s(%s)", c.Func, args)
+}
+
+func (c AggregateClause) String() string {
+	return c.Build()
+}
+
+// Values returns the values of the expression being aggregated, if any, and
+// the arguments of the aggregate followed by the values of the ordering.
+func (c AggregateClause) Values() []interface{} {
+	var vals []interface{}
+	if c.Expr != nil {
+		vals = append(vals, c.Expr.Values()...)
+	}
+	vals = append(vals, c.Args...)
+	return append(vals, c.Ordering.Values()...)
+}
+
+func (c AggregateClause) scalar() {}
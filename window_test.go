@@ -0,0 +1,54 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestWindow(t *testing.T) {
+	byCost := qb.OrderByClause{{Field: "cost", Dir: qb.Desc}}
+	cheapFirst := qb.OrderByClause{
+		qb.OrderByExpr(qb.Raw("CASE WHEN make = ? THEN 0 ELSE 1 END", "Honda"), qb.Asc),
+		{Field: "id"},
+	}
+
+	testcases := []testcase{
+		testcase{
+			name: "over",
+			query: qb.Select("vehicles", "id").
+				Expr(qb.As(qb.Over(qb.Raw("row_number()")).PartitionBy("make").Sort(byCost), "rank")),
+			want: output{
+				query: `SELECT id, row_number() OVER (PARTITION BY make ORDER BY cost DESC) AS rank FROM vehicles`,
+			},
+		},
+		testcase{
+			name:  "empty window",
+			query: qb.Over(qb.Raw("count(*)")),
+			want: output{
+				query: `count(*) OVER ()`,
+			},
+		},
+		testcase{
+			name: "string agg",
+			query: qb.Select("vehicles").
+				Expr(qb.As(qb.StringAgg("model", ", ").Sort(cheapFirst), "models")).
+				Where(qb.Equal("year", 2020)),
+			want: output{
+				query: `SELECT string_agg(model, ? ORDER BY CASE WHEN make = ? THEN 0 ELSE 1 END ASC, id) AS models FROM vehicles WHERE year = ?`,
+				vals:  []interface{}{", ", "Honda", 2020},
+			},
+		},
+		testcase{
+			name:  "expression order",
+			query: qb.Select("vehicles", "id").Where(qb.Equal("year", 2020)).Sort(cheapFirst),
+			want: output{
+				query: `SELECT id FROM vehicles WHERE year = ? ORDER BY CASE WHEN make = ? THEN 0 ELSE 1 END ASC, id`,
+				vals:  []interface{}{2020, "Honda"},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}
@@ -0,0 +1,52 @@
+package qb
+
+import "fmt"
+
+// XPath returns an expression that resolves to the form `xpath(?, field)`,
+// which evaluates the XPath expression against the XML document in the column
+// and returns an array of the matching nodes. The path is bound as a parameter.
+func XPath(field, path string) XMLClause {
+	return XMLClause{
+		Func:  "xpath",
+		Field: field,
+		Path:  path,
+	}
+}
+
+// XMLExists returns a boolean clause that resolves to the form `XMLEXISTS(?
+// PASSING BY VALUE field)`, which is true if the XPath expression matches any
+// node in the XML document in the column.
+func XMLExists(field, path string) XMLClause {
+	return XMLClause{
+		Func:  "XMLEXISTS",
+		Field: field,
+		Path:  path,
+	}
+}
+
+// XMLClause represents a call to one of the XPath functions on an XML column.
+type XMLClause struct {
+	Func  string
+	Field string
+	Path  string
+}
+
+// Build returns an expression of the form `fn(?, field)`, or `XMLEXISTS(?
+// PASSING BY VALUE field)`, whose syntax is defined by the SQL standard.
+func (c XMLClause) Build() string {
+	if c.Func == "XMLEXISTS" {
+		return fmt.Sprintf("XMLEXISTS(? PASSING BY VALUE %s)", c.Field)
+	}
+	return fmt.Sprintf("%s(?, %s)", c.Func, c.Field)
+}
+
+func (c XMLClause) String() string {
+	return c.Build()
+}
+
+// Values returns the path.
+func (c XMLClause) Values() []interface{} {
+	return []interface{}{c.Path}
+}
+
+func (c XMLClause) scalar() {}
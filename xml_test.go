@@ -0,0 +1,31 @@
+package qb_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/qb"
+)
+
+func TestXML(t *testing.T) {
+	testcases := []testcase{
+		testcase{
+			name:  "exists",
+			query: qb.Select("invoices", "id").Where(qb.XMLExists("body", "//line[@sku='A1']")),
+			want: output{
+				query: `SELECT id FROM invoices WHERE XMLEXISTS(? PASSING BY VALUE body)`,
+				vals:  []interface{}{"//line[@sku='A1']"},
+			},
+		},
+		testcase{
+			name:  "xpath",
+			query: qb.Select("invoices", "id").Expr(qb.As(qb.XPath("body", "/invoice/total/text()"), "total")),
+			want: output{
+				query: `SELECT id, xpath(?, body) AS total FROM invoices`,
+				vals:  []interface{}{"/invoice/total/text()"},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, test(tc))
+	}
+}